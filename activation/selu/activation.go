@@ -25,3 +25,6 @@ func (a *activation) Backward(v float64) float64 {
 	}
 	return 1
 }
+
+// BackwardOfInputs marks that Backward expects inputs of the activation.
+func (a *activation) BackwardOfInputs() {}
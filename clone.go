@@ -0,0 +1,22 @@
+package nnet
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+var ErrorLayerNotCloneable = errors.New("layer not cloneable")
+
+// CloneLayers returns clones of initialized layers sharing parameters with them.
+func CloneLayers(layers []Layer) ([]Layer, error) {
+	res := make([]Layer, len(layers))
+	for i, layer := range layers {
+		l, ok := layer.(LayerWithClone)
+		if !ok {
+			return nil, errors.Wrap(ErrorLayerNotCloneable, fmt.Sprintf("layer %d: %T", i, layer))
+		}
+		res[i] = l.Clone()
+	}
+	return res, nil
+}
@@ -0,0 +1,47 @@
+package data
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	ErrorBatchEmpty        = errors.New("batch is empty")
+	ErrorBatchDimsMismatch = errors.New("batch samples dims mismatch")
+)
+
+// NewBatch copies samples with equal dims into [w, h, d, n] hipercube.
+func NewBatch(samples ...*Data) (*Data, error) {
+	if len(samples) == 0 {
+		return nil, ErrorBatchEmpty
+	}
+
+	var w, h, d int
+	samples[0].Dimensions(&w, &h, &d)
+
+	res := &Data{}
+	res.InitHiperCube(w, h, d, len(samples))
+
+	volume := w * h * d
+	for i, sample := range samples {
+		var sw, sh, sd int
+		sample.Dimensions(&sw, &sh, &sd)
+
+		if sw != w || sh != h || sd != d || len(sample.Data) != volume {
+			return nil, errors.Wrap(ErrorBatchDimsMismatch, fmt.Sprintf("sample %d: %v, expected: %v", i, sample.Dims, samples[0].Dims))
+		}
+
+		copy(res.Data[i*volume:], sample.Data)
+	}
+
+	return res, nil
+}
+
+func MustNewBatch(samples ...*Data) *Data {
+	r, err := NewBatch(samples...)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
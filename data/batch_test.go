@@ -0,0 +1,71 @@
+package data
+
+import (
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestNewBatch(t *testing.T) {
+	type testCase struct {
+		samples       []*Data
+		expectedBatch *Data
+		expectedError error
+	}
+
+	testCases := map[string]testCase{
+		"empty": {
+			expectedError: ErrorBatchEmpty,
+		},
+		"dimsMismatch": {
+			samples:       NewVectors([]float64{1, 2}, []float64{1, 2, 3}),
+			expectedError: ErrorBatchDimsMismatch,
+		},
+		"vectors": {
+			samples: NewVectors([]float64{1, 2}, []float64{3, 4}, []float64{5, 6}),
+			expectedBatch: &Data{
+				Dims: []int{2, 1, 1, 3},
+				Data: []float64{1, 2, 3, 4, 5, 6},
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			actualBatch, actualError := NewBatch(tc.samples...)
+			if tc.expectedError != nil {
+				assert.Nil(t, actualBatch)
+				assert.Equal(t, tc.expectedError, errors.Cause(actualError))
+			} else {
+				assert.Equal(t, tc.expectedBatch, actualBatch)
+				assert.NoError(t, actualError)
+			}
+		})
+	}
+}
+
+func TestData_GetSample(t *testing.T) {
+	batch := MustNewBatch(NewVectors([]float64{1, 2}, []float64{3, 4})...)
+
+	assert.Equal(t, 2, batch.GetBatchSize())
+	assert.Equal(t, 1, NewVector(1, 2).GetBatchSize())
+
+	sample := batch.GetSample(1)
+	assert.Equal(t, NewVector(3, 4), sample)
+
+	// check than sample is linked to the batch
+	sample.Data[0] = 7
+	assert.Equal(t, 7.0, batch.Data[2])
+}
+
+func TestData_InitBatch(t *testing.T) {
+	single := &Data{}
+	single.InitBatch(2, 3, 4, 1)
+	assert.Equal(t, []int{2, 3, 4}, single.Dims)
+
+	batch := &Data{}
+	batch.InitBatch(2, 3, 4, 5)
+	assert.Equal(t, []int{2, 3, 4, 5}, batch.Dims)
+	assert.Equal(t, 120, len(batch.Data))
+}
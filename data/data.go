@@ -78,6 +78,28 @@ func (m *Data) InitHiperCubeRandom(w, h, d, t int, min, max float64) {
 	m.FillRandom(min, max)
 }
 
+// InitBatch inits cube for a single sample or hipercube of n cubes for a batch.
+func (m *Data) InitBatch(w, h, d, n int) {
+	if n == 1 {
+		m.InitCube(w, h, d)
+	} else {
+		m.InitHiperCube(w, h, d, n)
+	}
+}
+
+// GetBatchSize returns count of cubes in data, the fourth dimension.
+func (m *Data) GetBatchSize() int {
+	if len(m.Dims) > 3 {
+		return m.Dims[3]
+	}
+	return 1
+}
+
+// GetSample returns cube of the batch, result is linked to the batch data.
+func (m *Data) GetSample(index int) *Data {
+	return m.GetTensor(index, m.Dims[2])
+}
+
 func (m *Data) CopyZero() (r *Data) {
 	r = &Data{}
 	r.Dims = make([]int, len(m.Dims))
@@ -0,0 +1,35 @@
+package data
+
+// Data32 is Data with float32 values, float32 layers store their parameters in it.
+type Data32 struct {
+	Dims []int
+	Data []float32
+}
+
+// Float32 returns copy of data rounded to float32.
+func (m *Data) Float32() *Data32 {
+	r := &Data32{
+		Dims: make([]int, len(m.Dims)),
+		Data: make([]float32, len(m.Data)),
+	}
+	copy(r.Dims, m.Dims)
+
+	for i, v := range m.Data {
+		r.Data[i] = float32(v)
+	}
+	return r
+}
+
+// Float64 returns copy of data converted to float64.
+func (m *Data32) Float64() *Data {
+	r := &Data{
+		Dims: make([]int, len(m.Dims)),
+		Data: make([]float64, len(m.Data)),
+	}
+	copy(r.Dims, m.Dims)
+
+	for i, v := range m.Data {
+		r.Data[i] = float64(v)
+	}
+	return r
+}
@@ -0,0 +1,23 @@
+package data
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestData_Float32(t *testing.T) {
+	d := &Data{Dims: []int{3, 1, 1}, Data: []float64{0.1, -2.5, 1e-3}}
+
+	d32 := d.Float32()
+	assert.Equal(t, &Data32{Dims: []int{3, 1, 1}, Data: []float32{0.1, -2.5, 1e-3}}, d32)
+
+	d32.Dims[0] = 4
+	assert.Equal(t, []int{3, 1, 1}, d.Dims, "result is not linked to the source")
+
+	d32.Dims[0] = 3
+	assert.Equal(t, &Data{
+		Dims: []int{3, 1, 1},
+		Data: []float64{float64(float32(0.1)), -2.5, float64(float32(1e-3))},
+	}, d32.Float64())
+}
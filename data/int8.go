@@ -0,0 +1,77 @@
+package data
+
+import "math"
+
+// Int8 holds values quantized to int8 with a scale per channel, channels are equal
+// consecutive parts of Data and value is Data[i] multiplied by scale of its channel.
+type Int8 struct {
+	Dims   []int
+	Data   []int8
+	Scales []float64
+}
+
+// MaxInt8Products is max count of products of int8 values, which sum fits int32.
+const MaxInt8Products = math.MaxInt32 / (128 * 128)
+
+// Int8Scale returns scale mapping values of range [-maxAbs, maxAbs] to [-127, 127].
+func Int8Scale(maxAbs float64) float64 {
+	if maxAbs == 0 {
+		return 1
+	}
+	return maxAbs / 127
+}
+
+// QuantizeInt8 returns value divided by scale, rounded and clamped to [-127, 127].
+func QuantizeInt8(v, scale float64) int8 {
+	q := math.Round(v / scale)
+	if q > 127 {
+		return 127
+	}
+	if q < -127 {
+		return -127
+	}
+	return int8(q)
+}
+
+func (m *Data) GetMaxAbsValue(fromIndex, toIndex int) float64 {
+	res := 0.0
+	for i := fromIndex; i < toIndex; i++ {
+		res = math.Max(res, math.Abs(m.Data[i]))
+	}
+	return res
+}
+
+// Int8 returns data quantized with a symmetric scale per channel.
+func (m *Data) Int8(channels int) *Int8 {
+	r := &Int8{
+		Dims:   make([]int, len(m.Dims)),
+		Data:   make([]int8, len(m.Data)),
+		Scales: make([]float64, channels),
+	}
+	copy(r.Dims, m.Dims)
+
+	size := len(m.Data) / channels
+	for c := range r.Scales {
+		r.Scales[c] = Int8Scale(m.GetMaxAbsValue(c*size, (c+1)*size))
+
+		for i := c * size; i < (c+1)*size; i++ {
+			r.Data[i] = QuantizeInt8(m.Data[i], r.Scales[c])
+		}
+	}
+	return r
+}
+
+// Float64 returns dequantized copy of data.
+func (m *Int8) Float64() *Data {
+	r := &Data{
+		Dims: make([]int, len(m.Dims)),
+		Data: make([]float64, len(m.Data)),
+	}
+	copy(r.Dims, m.Dims)
+
+	size := len(m.Data) / len(m.Scales)
+	for i, v := range m.Data {
+		r.Data[i] = float64(v) * m.Scales[i/size]
+	}
+	return r
+}
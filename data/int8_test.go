@@ -0,0 +1,25 @@
+package data
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuantizeInt8(t *testing.T) {
+	assert.Equal(t, int8(64), QuantizeInt8(0.5, Int8Scale(1)))
+	assert.Equal(t, int8(-127), QuantizeInt8(-1, Int8Scale(1)))
+	assert.Equal(t, int8(127), QuantizeInt8(3, Int8Scale(1)), "value is clamped")
+	assert.Equal(t, int8(0), QuantizeInt8(0, Int8Scale(0)))
+}
+
+func TestData_Int8(t *testing.T) {
+	d := &Data{Dims: []int{3, 2, 1}, Data: []float64{0.5, -1, 0.25, 2, 0, 0}}
+
+	q := d.Int8(2)
+	assert.Equal(t, []int{3, 2, 1}, q.Dims)
+	assert.Equal(t, []float64{1.0 / 127, 2.0 / 127}, q.Scales)
+	assert.Equal(t, []int8{64, -127, 32, 127, 0, 0}, q.Data)
+
+	assert.InDeltaSlice(t, d.Data, q.Float64().Data, 1.0/127)
+}
@@ -0,0 +1,207 @@
+package data
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	ErrorShapeMismatch   = errors.New("shape mismatch")
+	ErrorIndexOutOfRange = errors.New("index out of range")
+	ErrorAxisOutOfRange  = errors.New("axis out of range")
+	ErrorTensorsEmpty    = errors.New("no tensors")
+)
+
+// Shape returns copy of the dims.
+func (m *Data) Shape() []int {
+	res := make([]int, len(m.Dims))
+	copy(res, m.Dims)
+	return res
+}
+
+// Reshape returns data with new dims linked to the same values, dims must be positive
+// and their volume must be equal to length of data.
+func (m *Data) Reshape(dims ...int) (*Data, error) {
+	if !positive(dims) || volume(dims) != len(m.Data) {
+		return nil, errors.Wrap(ErrorShapeMismatch, fmt.Sprintf("%v to %v", m.Dims, dims))
+	}
+
+	res := &Data{Dims: make([]int, len(dims)), Data: m.Data}
+	copy(res.Dims, dims)
+	return res, nil
+}
+
+// At returns value by index of every dimension, x is the first.
+func (m *Data) At(index ...int) (float64, error) {
+	i, err := m.offset(index)
+	if err != nil {
+		return 0, err
+	}
+	return m.Data[i], nil
+}
+
+func (m *Data) Set(value float64, index ...int) error {
+	i, err := m.offset(index)
+	if err != nil {
+		return err
+	}
+	m.Data[i] = value
+	return nil
+}
+
+func (m *Data) offset(index []int) (int, error) {
+	if err := m.checkVolume(); err != nil {
+		return 0, err
+	}
+	return offset(m.Dims, strides(m.Dims), 0, index)
+}
+
+// checkVolume returns error when dims are not positive or count of values differs from their volume.
+func (m *Data) checkVolume() error {
+	if !positive(m.Dims) || volume(m.Dims) != len(m.Data) {
+		return errors.Wrap(ErrorShapeMismatch, fmt.Sprintf("dims %v, length %d", m.Dims, len(m.Data)))
+	}
+	return nil
+}
+
+// View returns strided view of the data.
+func (m *Data) View() (*View, error) {
+	if err := m.checkVolume(); err != nil {
+		return nil, err
+	}
+
+	return &View{
+		Dims:    m.Shape(),
+		Strides: strides(m.Dims),
+		Data:    m.Data,
+	}, nil
+}
+
+func (m *Data) Slice(axis, from, to int) (*View, error) {
+	v, err := m.View()
+	if err != nil {
+		return nil, err
+	}
+	return v.Slice(axis, from, to)
+}
+
+func (m *Data) Transpose() (*View, error) {
+	v, err := m.View()
+	if err != nil {
+		return nil, err
+	}
+	return v.Transpose()
+}
+
+func (m *Data) Permute(axes ...int) (*View, error) {
+	v, err := m.View()
+	if err != nil {
+		return nil, err
+	}
+	return v.Permute(axes...)
+}
+
+// Concat copies tensors with equal dims except the axis into a new one.
+func Concat(axis int, tensors ...*Data) (*Data, error) {
+	if len(tensors) == 0 {
+		return nil, ErrorTensorsEmpty
+	}
+
+	dims := tensors[0].Shape()
+	if axis < 0 || axis >= len(dims) {
+		return nil, errors.Wrap(ErrorAxisOutOfRange, fmt.Sprintf("axis %d, dims %v", axis, dims))
+	}
+
+	dims[axis] = 0
+	for i, t := range tensors {
+		if !equalExcept(t.Dims, tensors[0].Dims, axis) || t.checkVolume() != nil {
+			return nil, errors.Wrap(ErrorShapeMismatch, fmt.Sprintf("tensor %d: %v, expected: %v", i, t.Dims, tensors[0].Dims))
+		}
+		dims[axis] += t.Dims[axis]
+	}
+
+	// values are copied by blocks of all dimensions up to the axis
+	inner := volume(dims[:axis])
+	outer := volume(dims[axis+1:])
+
+	res := &Data{Dims: dims, Data: make([]float64, volume(dims))}
+
+	pos := 0
+	for o := 0; o < outer; o++ {
+		for _, t := range tensors {
+			block := inner * t.Dims[axis]
+			pos += copy(res.Data[pos:], t.Data[o*block:(o+1)*block])
+		}
+	}
+
+	return res, nil
+}
+
+// Stack copies tensors with equal dims into a new one with an additional last dimension,
+// so stacked cubes make a batch.
+func Stack(tensors ...*Data) (*Data, error) {
+	if len(tensors) == 0 {
+		return nil, ErrorTensorsEmpty
+	}
+
+	expanded := make([]*Data, len(tensors))
+	for i, t := range tensors {
+		expanded[i] = &Data{Dims: append(t.Shape(), 1), Data: t.Data}
+	}
+
+	return Concat(len(tensors[0].Dims), expanded...)
+}
+
+func positive(dims []int) bool {
+	for _, d := range dims {
+		if d < 1 {
+			return false
+		}
+	}
+	return true
+}
+
+func volume(dims []int) int {
+	res := 1
+	for _, d := range dims {
+		res *= d
+	}
+	return res
+}
+
+func strides(dims []int) []int {
+	res := make([]int, len(dims))
+	stride := 1
+	for i, d := range dims {
+		res[i] = stride
+		stride *= d
+	}
+	return res
+}
+
+func offset(dims, strides []int, offset int, index []int) (int, error) {
+	if len(index) != len(dims) {
+		return 0, errors.Wrap(ErrorShapeMismatch, fmt.Sprintf("index %v, dims %v", index, dims))
+	}
+
+	for i, v := range index {
+		if v < 0 || v >= dims[i] {
+			return 0, errors.Wrap(ErrorIndexOutOfRange, fmt.Sprintf("index %v, dims %v", index, dims))
+		}
+		offset += v * strides[i]
+	}
+	return offset, nil
+}
+
+func equalExcept(a, b []int, axis int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if i != axis && a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
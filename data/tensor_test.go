@@ -0,0 +1,98 @@
+package data
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func cube() *Data {
+	return &Data{Dims: []int{3, 2, 2}, Data: []float64{
+		0, 1, 2,
+		3, 4, 5,
+
+		6, 7, 8,
+		9, 10, 11,
+	}}
+}
+
+func TestData_Reshape(t *testing.T) {
+	d := cube()
+
+	r, err := d.Reshape(6, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{6, 2}, r.Shape())
+	assert.Equal(t, []int{3, 2, 2}, d.Shape())
+
+	r.Data[0] = -1
+	assert.Equal(t, -1.0, d.Data[0], "result is linked to the data")
+
+	for _, dims := range [][]int{{5, 2}, {-2, -6}, {-3, 2, -2}, {12, 0}, {}} {
+		_, err = d.Reshape(dims...)
+		assert.Equal(t, ErrorShapeMismatch, errors.Cause(err), "dims: %v", dims)
+	}
+
+	_, err = (&Data{}).Reshape(0)
+	assert.Equal(t, ErrorShapeMismatch, errors.Cause(err), "zero dims of empty data")
+}
+
+func TestData_At(t *testing.T) {
+	d := cube()
+
+	v, err := d.At(1, 1, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, 10.0, v)
+
+	assert.NoError(t, d.Set(-4, 1, 1, 0))
+	assert.Equal(t, -4.0, d.Data[4])
+
+	_, err = d.At(3, 0, 0)
+	assert.Equal(t, ErrorIndexOutOfRange, errors.Cause(err))
+
+	_, err = d.At(0, 0)
+	assert.Equal(t, ErrorShapeMismatch, errors.Cause(err))
+
+	err = (&Data{Dims: []int{2, 2, 1}, Data: []float64{1}}).Set(0, 1, 1, 0)
+	assert.Equal(t, ErrorShapeMismatch, errors.Cause(err), "data is shorter than dims")
+}
+
+func TestConcat(t *testing.T) {
+	a := &Data{Dims: []int{2, 2}, Data: []float64{1, 2, 3, 4}}
+	b := &Data{Dims: []int{1, 2}, Data: []float64{5, 6}}
+
+	res, err := Concat(0, a, b)
+	assert.NoError(t, err)
+	assert.Equal(t, &Data{Dims: []int{3, 2}, Data: []float64{1, 2, 5, 3, 4, 6}}, res)
+
+	res, err = Concat(1, a, a)
+	assert.NoError(t, err)
+	assert.Equal(t, &Data{Dims: []int{2, 4}, Data: []float64{1, 2, 3, 4, 1, 2, 3, 4}}, res)
+
+	_, err = Concat(1, a, b)
+	assert.Equal(t, ErrorShapeMismatch, errors.Cause(err))
+
+	_, err = Concat(2, a, b)
+	assert.Equal(t, ErrorAxisOutOfRange, errors.Cause(err))
+
+	negative := &Data{Dims: []int{-2, -2}, Data: []float64{1, 2, 3, 4}}
+	_, err = Concat(0, negative, negative)
+	assert.Equal(t, ErrorShapeMismatch, errors.Cause(err))
+
+	_, err = Concat(0)
+	assert.Equal(t, ErrorTensorsEmpty, err)
+}
+
+func TestStack(t *testing.T) {
+	a, b := NewVector(1, 2), NewVector(3, 4)
+
+	res, err := Stack(a, b)
+	assert.NoError(t, err)
+	assert.Equal(t, MustNewBatch(a, b), res)
+
+	_, err = Stack(a, NewVector(1))
+	assert.Equal(t, ErrorShapeMismatch, errors.Cause(err))
+
+	_, err = Stack()
+	assert.Equal(t, ErrorTensorsEmpty, err)
+}
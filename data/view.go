@@ -0,0 +1,125 @@
+package data
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+// View is a strided view of data values, slices and permutations of a view are linked to the same values.
+type View struct {
+	Dims    []int
+	Strides []int
+	Offset  int
+	Data    []float64
+}
+
+func (v *View) Shape() []int {
+	res := make([]int, len(v.Dims))
+	copy(res, v.Dims)
+	return res
+}
+
+func (v *View) At(index ...int) (float64, error) {
+	i, err := offset(v.Dims, v.Strides, v.Offset, index)
+	if err != nil {
+		return 0, err
+	}
+	return v.Data[i], nil
+}
+
+func (v *View) Set(value float64, index ...int) error {
+	i, err := offset(v.Dims, v.Strides, v.Offset, index)
+	if err != nil {
+		return err
+	}
+	v.Data[i] = value
+	return nil
+}
+
+// Slice returns view of indexes from..to-1 along the axis.
+func (v *View) Slice(axis, from, to int) (*View, error) {
+	if axis < 0 || axis >= len(v.Dims) {
+		return nil, errors.Wrap(ErrorAxisOutOfRange, fmt.Sprintf("axis %d, dims %v", axis, v.Dims))
+	}
+	if from < 0 || from > to || to > v.Dims[axis] {
+		return nil, errors.Wrap(ErrorIndexOutOfRange, fmt.Sprintf("slice %d:%d, dims %v", from, to, v.Dims))
+	}
+
+	res := v.clone()
+	res.Dims[axis] = to - from
+	res.Offset += from * v.Strides[axis]
+	return res, nil
+}
+
+// Transpose swaps the first two dimensions, so rows of a matrix become columns.
+func (v *View) Transpose() (*View, error) {
+	if len(v.Dims) < 2 {
+		return nil, errors.Wrap(ErrorAxisOutOfRange, fmt.Sprintf("dims %v", v.Dims))
+	}
+
+	axes := make([]int, len(v.Dims))
+	for i := range axes {
+		axes[i] = i
+	}
+	axes[0], axes[1] = 1, 0
+
+	return v.Permute(axes...)
+}
+
+// Permute returns view with dimension i of the result equal to dimension axes[i] of the view.
+func (v *View) Permute(axes ...int) (*View, error) {
+	if len(axes) != len(v.Dims) {
+		return nil, errors.Wrap(ErrorShapeMismatch, fmt.Sprintf("axes %v, dims %v", axes, v.Dims))
+	}
+
+	res := v.clone()
+	used := make([]bool, len(axes))
+
+	for i, axis := range axes {
+		if axis < 0 || axis >= len(axes) || used[axis] {
+			return nil, errors.Wrap(ErrorAxisOutOfRange, fmt.Sprintf("axes %v", axes))
+		}
+		used[axis] = true
+
+		res.Dims[i], res.Strides[i] = v.Dims[axis], v.Strides[axis]
+	}
+	return res, nil
+}
+
+// Copy returns values of the view as a new contiguous data.
+func (v *View) Copy() *Data {
+	res := &Data{Dims: v.Shape(), Data: make([]float64, volume(v.Dims))}
+
+	index := make([]int, len(v.Dims))
+	pos := v.Offset
+
+	for i := range res.Data {
+		res.Data[i] = v.Data[pos]
+
+		for axis := range index {
+			index[axis]++
+			pos += v.Strides[axis]
+
+			if index[axis] < v.Dims[axis] {
+				break
+			}
+
+			pos -= v.Dims[axis] * v.Strides[axis]
+			index[axis] = 0
+		}
+	}
+
+	return res
+}
+
+func (v *View) clone() *View {
+	res := &View{
+		Dims:    v.Shape(),
+		Strides: make([]int, len(v.Strides)),
+		Offset:  v.Offset,
+		Data:    v.Data,
+	}
+	copy(res.Strides, v.Strides)
+	return res
+}
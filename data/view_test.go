@@ -0,0 +1,104 @@
+package data
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestData_View(t *testing.T) {
+	v, err := cube().View()
+	assert.NoError(t, err)
+	assert.Equal(t, cube(), v.Copy())
+
+	broken := &Data{Dims: []int{3, 2}, Data: []float64{1, 2, 3, 4, 5}}
+
+	_, err = broken.View()
+	assert.Equal(t, ErrorShapeMismatch, errors.Cause(err))
+
+	_, err = broken.Slice(0, 0, 1)
+	assert.Equal(t, ErrorShapeMismatch, errors.Cause(err))
+
+	_, err = broken.Transpose()
+	assert.Equal(t, ErrorShapeMismatch, errors.Cause(err))
+
+	_, err = broken.Permute(1, 0)
+	assert.Equal(t, ErrorShapeMismatch, errors.Cause(err))
+
+	for _, dims := range [][]int{{-2, -3}, {-6, -1}, {0, 3}} {
+		_, err = (&Data{Dims: dims, Data: []float64{1, 2, 3, 4, 5, 6}}).View()
+		assert.Equal(t, ErrorShapeMismatch, errors.Cause(err), "dims: %v", dims)
+	}
+
+	_, err = (&Data{Dims: []int{0, 3}}).View()
+	assert.Equal(t, ErrorShapeMismatch, errors.Cause(err), "zero dims of empty data")
+}
+
+func TestView_Slice(t *testing.T) {
+	d := cube()
+
+	v, err := d.Slice(0, 1, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{2, 2, 2}, v.Shape())
+	assert.Equal(t, []float64{1, 2, 4, 5, 7, 8, 10, 11}, v.Copy().Data)
+
+	v, err = v.Slice(2, 1, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, &Data{Dims: []int{2, 2, 1}, Data: []float64{7, 8, 10, 11}}, v.Copy())
+
+	assert.NoError(t, v.Set(-1, 1, 1, 0))
+	assert.Equal(t, -1.0, d.Data[11], "view is linked to the data")
+
+	_, err = v.At(0, 0, 1)
+	assert.Equal(t, ErrorIndexOutOfRange, errors.Cause(err))
+
+	_, err = d.Slice(1, 1, 3)
+	assert.Equal(t, ErrorIndexOutOfRange, errors.Cause(err))
+
+	_, err = d.Slice(3, 0, 1)
+	assert.Equal(t, ErrorAxisOutOfRange, errors.Cause(err))
+}
+
+func TestView_Transpose(t *testing.T) {
+	d := &Data{Dims: []int{3, 2}, Data: []float64{
+		1, 2, 3,
+		4, 5, 6,
+	}}
+
+	v, err := d.Transpose()
+	assert.NoError(t, err)
+
+	value, err := v.At(1, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, 6.0, value)
+
+	assert.Equal(t, &Data{Dims: []int{2, 3}, Data: []float64{
+		1, 4,
+		2, 5,
+		3, 6,
+	}}, v.Copy())
+
+	_, err = (&Data{Dims: []int{3}, Data: []float64{1, 2, 3}}).Transpose()
+	assert.Equal(t, ErrorAxisOutOfRange, errors.Cause(err))
+}
+
+func TestView_Permute(t *testing.T) {
+	d := cube()
+
+	v, err := d.Permute(2, 0, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{2, 3, 2}, v.Shape())
+
+	value, err := v.At(1, 2, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 8.0, value)
+
+	assert.Equal(t, []float64{0, 6, 1, 7, 2, 8, 3, 9, 4, 10, 5, 11}, v.Copy().Data)
+
+	_, err = d.Permute(0, 0, 1)
+	assert.Equal(t, ErrorAxisOutOfRange, errors.Cause(err))
+
+	_, err = d.Permute(0, 1)
+	assert.Equal(t, ErrorShapeMismatch, errors.Cause(err))
+}
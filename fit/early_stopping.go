@@ -0,0 +1,134 @@
+package fit
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/drdreyworld/nnet/data"
+	"github.com/drdreyworld/nnet/trainer"
+	"github.com/pkg/errors"
+)
+
+var ErrorLossNaN = errors.New("loss is NaN, training diverged or there is no validation data")
+
+// LayerWithStatistics is implemented by layers keeping statistics of the training data
+// besides weights and biases, like running mean and variance of batch normalization.
+type LayerWithStatistics interface {
+	GetStatistics() []*data.Data
+}
+
+// NewEarlyStopping creates early stopping which keeps copy of the net weights, biases and statistics
+// with the best metric and stops after patience checks without improvement by more than minDelta.
+// It stops immediately on NaN metric, Err returns the reason. The best parameters are restored
+// into the net when it stops.
+func NewEarlyStopping(net trainer.Net, patience int, minDelta float64) *earlyStopping {
+	return &earlyStopping{
+		net:       net,
+		patience:  patience,
+		minDelta:  minDelta,
+		bestLoss:  math.Inf(1),
+		bestEpoch: -1,
+	}
+}
+
+type earlyStopping struct {
+	net      trainer.Net
+	patience int
+	minDelta float64
+
+	epoch     int
+	bestEpoch int
+	bestLoss  float64
+	wait      int
+
+	best []*data.Data
+	err  error
+}
+
+// Check registers metric of the next epoch, lower is better, and returns true when training should stop,
+// the net has the best parameters then.
+func (e *earlyStopping) Check(loss float64) (stop bool) {
+	epoch := e.epoch
+	e.epoch++
+
+	if math.IsNaN(loss) {
+		e.err = errors.Wrap(ErrorLossNaN, fmt.Sprintf("epoch %d", epoch))
+		e.Restore()
+		return true
+	}
+
+	if loss < e.bestLoss-e.minDelta {
+		e.bestLoss = loss
+		e.bestEpoch = epoch
+		e.wait = 0
+		e.save()
+		return false
+	}
+
+	e.wait++
+	if e.wait > e.patience {
+		e.Restore()
+		return true
+	}
+	return false
+}
+
+// OnEpochEnd checks validation loss of the epoch, it can be passed to fit.OnEpochEnd.
+func (e *earlyStopping) OnEpochEnd(stats Stats) bool {
+	return e.Check(stats.ValidationLoss)
+}
+
+// Restore copies the best weights, biases and statistics back to the net, Check does it on stop.
+func (e *earlyStopping) Restore() {
+	if e.best == nil {
+		return
+	}
+
+	for i, v := range e.values() {
+		copy(v.Data, e.best[i].Data)
+	}
+}
+
+// Err returns ErrorLossNaN when Check stopped on NaN metric.
+func (e *earlyStopping) Err() error {
+	return e.err
+}
+
+func (e *earlyStopping) GetBestLoss() float64 {
+	return e.bestLoss
+}
+
+// GetBestEpoch returns index of the best check or -1 when nothing was saved.
+func (e *earlyStopping) GetBestEpoch() int {
+	return e.bestEpoch
+}
+
+func (e *earlyStopping) save() {
+	values := e.values()
+
+	if e.best == nil {
+		e.best = make([]*data.Data, len(values))
+		for i, v := range values {
+			e.best[i] = v.CopyZero()
+		}
+	}
+
+	for i, v := range values {
+		copy(e.best[i].Data, v.Data)
+	}
+}
+
+// values returns weights, biases and statistics of the net layers.
+func (e *earlyStopping) values() []*data.Data {
+	res := []*data.Data{}
+	for _, p := range trainer.GetParams(e.net) {
+		res = append(res, p.Value)
+	}
+
+	for i := 0; i < e.net.GetLayersCount(); i++ {
+		if layer, ok := e.net.GetLayer(i).(LayerWithStatistics); ok {
+			res = append(res, layer.GetStatistics()...)
+		}
+	}
+	return res
+}
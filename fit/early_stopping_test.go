@@ -0,0 +1,134 @@
+package fit
+
+import (
+	"math"
+	"testing"
+
+	"github.com/drdreyworld/nnet/data"
+	"github.com/drdreyworld/nnet/layer/batchnorm"
+	"github.com/drdreyworld/nnet/layer/fc"
+	"github.com/drdreyworld/nnet/loss/regression"
+	basic_ffn "github.com/drdreyworld/nnet/net/basic-ffn"
+	"github.com/drdreyworld/nnet/optimizer/sgd"
+	"github.com/drdreyworld/nnet/trainer"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEarlyStopping_Check(t *testing.T) {
+	layer := fc.New()
+	net := basic_ffn.New(2, 1, 1, basic_ffn.Layers{layer})
+	assert.NoError(t, net.Init())
+
+	es := NewEarlyStopping(net, 1, 0.01)
+	assert.Equal(t, -1, es.GetBestEpoch())
+
+	layer.Weights.Data = []float64{1, 1}
+	assert.False(t, es.Check(0.5))
+
+	layer.Weights.Data = []float64{2, 2}
+	assert.False(t, es.Check(0.3))
+
+	// improvement less than min delta
+	layer.Weights.Data = []float64{3, 3}
+	assert.False(t, es.Check(0.295))
+
+	layer.Weights.Data = []float64{4, 4}
+	assert.True(t, es.Check(0.4))
+	assert.Equal(t, []float64{2, 2}, layer.Weights.Data, "best weights are restored on stop")
+
+	assert.Equal(t, 1, es.GetBestEpoch())
+	assert.Equal(t, 0.3, es.GetBestLoss())
+}
+
+func TestEarlyStopping_Fit(t *testing.T) {
+	net := basic_ffn.New(2, 1, 1, basic_ffn.Layers{fc.New()})
+	assert.NoError(t, net.Init())
+
+	// too big learning rate makes validation loss grow
+	tr := trainer.New(net, regression.New(), sgd.New(3, 0, 0))
+	es := NewEarlyStopping(net, 3, 0)
+
+	dataset := newLinearDataset(20)
+	history, err := Fit(tr, net, regression.New(), dataset,
+		Epochs(100),
+		ValidationSplit(0.25),
+		OnEpochEnd(es.OnEpochEnd),
+	)
+
+	assert.NoError(t, err)
+	assert.Len(t, history, es.GetBestEpoch()+5)
+
+	best := math.Inf(1)
+	for _, stats := range history {
+		best = math.Min(best, stats.ValidationLoss)
+	}
+	assert.Equal(t, best, es.GetBestLoss())
+
+	validation := Dataset{Inputs: dataset.Inputs[15:], Targets: dataset.Targets[15:]}
+	assert.Equal(t, es.GetBestLoss(), Evaluate(net, regression.New(), validation))
+}
+
+func TestEarlyStopping_RestoreWithoutSave(t *testing.T) {
+	layer := fc.New()
+	net := basic_ffn.New(2, 1, 1, basic_ffn.Layers{layer})
+	assert.NoError(t, net.Init())
+
+	layer.Weights = data.NewVector(1, 2)
+
+	es := NewEarlyStopping(net, 0, 0)
+	es.Restore()
+
+	assert.Equal(t, data.NewVector(1, 2), layer.Weights)
+}
+
+func TestEarlyStopping_NaN(t *testing.T) {
+	net := basic_ffn.New(2, 1, 1, basic_ffn.Layers{fc.New()})
+	assert.NoError(t, net.Init())
+
+	es := NewEarlyStopping(net, 5, 0)
+	assert.False(t, es.Check(0.5))
+	assert.NoError(t, es.Err())
+
+	layer := net.GetLayer(0).(trainer.TrainableLayer)
+	w, _ := layer.GetWeightsWithGradient()
+	expected := w.Copy()
+	w.Fill(math.NaN())
+
+	assert.True(t, es.Check(math.NaN()))
+	assert.Equal(t, ErrorLossNaN, errors.Cause(es.Err()))
+	assert.Equal(t, 0, es.GetBestEpoch())
+	assert.Equal(t, expected, w, "weights of the best epoch are restored")
+}
+
+func TestEarlyStopping_FitWithoutValidation(t *testing.T) {
+	net := basic_ffn.New(2, 1, 1, basic_ffn.Layers{fc.New()})
+	assert.NoError(t, net.Init())
+
+	tr := trainer.New(net, regression.New(), sgd.New(0.1, 0, 0))
+	es := NewEarlyStopping(net, 5, 0)
+
+	history, err := Fit(tr, net, regression.New(), newLinearDataset(4), Epochs(10), OnEpochEnd(es.OnEpochEnd))
+	assert.NoError(t, err)
+	assert.Len(t, history, 1)
+	assert.Equal(t, ErrorLossNaN, errors.Cause(es.Err()))
+}
+
+func TestEarlyStopping_RestoreStatistics(t *testing.T) {
+	layer := batchnorm.New()
+	net := basic_ffn.New(2, 1, 1, basic_ffn.Layers{layer})
+	assert.NoError(t, net.Init())
+
+	es := NewEarlyStopping(net, 1, 0)
+
+	layer.RunningMean.Fill(0.5)
+	assert.False(t, es.Check(0.3))
+
+	layer.RunningMean.Fill(0.7)
+	layer.RunningVar.Fill(2)
+	assert.False(t, es.Check(0.4))
+
+	es.Restore()
+	assert.Equal(t, []float64{0.5}, layer.RunningMean.Data)
+	assert.Equal(t, []float64{1}, layer.RunningVar.Data)
+}
@@ -0,0 +1,257 @@
+package fit
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+
+	"github.com/drdreyworld/nnet/data"
+	"github.com/pkg/errors"
+)
+
+var (
+	ErrorDatasetEmpty        = errors.New("dataset is empty")
+	ErrorDatasetSizeMismatch = errors.New("dataset inputs and targets count mismatch")
+	ErrorValidationSplit     = errors.New("validation split must be in range [0, 1)")
+)
+
+type Trainer interface {
+	Activate(inputs, target *data.Data) (output *data.Data)
+	UpdateWeights()
+}
+
+type Net interface {
+	Activate(inputs *data.Data) (output *data.Data)
+}
+
+// NetWithMode is implemented by nets with layers behaving differently in training and inference,
+// Fit evaluates validation data in eval mode and trains in train mode. Modes of trainers implementing
+// it are switched too, so replicas of the net in the parallel trainer follow the net.
+type NetWithMode interface {
+	Train()
+	Eval()
+}
+
+// TrainerWithObserve is implemented by trainers with schedules depending on a metric, like reduce
+// on plateau. Fit passes them validation loss, or train loss without validation data, after every epoch.
+type TrainerWithObserve interface {
+	Observe(metric float64)
+}
+
+type Loss interface {
+	GetError(target, output []float64) float64
+}
+
+type Dataset struct {
+	Inputs  []*data.Data
+	Targets []*data.Data
+}
+
+func (d Dataset) Len() int {
+	return len(d.Inputs)
+}
+
+func (d Dataset) validate() error {
+	if len(d.Inputs) != len(d.Targets) {
+		return errors.Wrap(ErrorDatasetSizeMismatch, fmt.Sprintf("inputs: %d, targets: %d", len(d.Inputs), len(d.Targets)))
+	}
+	return nil
+}
+
+// Stats of the epoch, losses are means of the loss GetError over samples.
+// ValidationLoss is NaN when there is no validation data.
+type Stats struct {
+	Epoch          int
+	TrainLoss      float64
+	ValidationLoss float64
+}
+
+// Fit trains the net with the trainer for configured count of epochs, evaluates
+// validation data by the loss after each epoch and returns stats of every epoch.
+// Trainer must train the same net which is passed for validation.
+func Fit(trainer Trainer, net Net, loss Loss, dataset Dataset, options ...Option) ([]Stats, error) {
+	f := &fit{
+		trainer: trainer,
+		net:     net,
+		loss:    loss,
+	}
+	defaults(f)
+
+	for _, opt := range options {
+		opt(f)
+	}
+
+	if err := dataset.validate(); err != nil {
+		return nil, err
+	}
+
+	if !(f.validationSplit >= 0 && f.validationSplit < 1) {
+		return nil, errors.Wrap(ErrorValidationSplit, fmt.Sprintf("%v", f.validationSplit))
+	}
+
+	train, validation := dataset, f.validation
+	if f.validationSplit > 0 {
+		count := int(math.Round(float64(dataset.Len()) * f.validationSplit))
+		split := dataset.Len() - count
+
+		train = Dataset{Inputs: dataset.Inputs[:split], Targets: dataset.Targets[:split]}
+		validation = Dataset{Inputs: dataset.Inputs[split:], Targets: dataset.Targets[split:]}
+	}
+
+	if train.Len() == 0 {
+		return nil, ErrorDatasetEmpty
+	}
+
+	if err := validation.validate(); err != nil {
+		return nil, err
+	}
+
+	return f.run(train, validation)
+}
+
+type fit struct {
+	trainer Trainer
+	net     Net
+	loss    Loss
+
+	epochs    int
+	batchSize int
+
+	shuffle bool
+	seed    int64
+
+	validationSplit float64
+	validation      Dataset
+
+	onBatchEnd []BatchCallback
+	onEpochEnd []EpochCallback
+}
+
+func (f *fit) run(train, validation Dataset) ([]Stats, error) {
+	history := []Stats{}
+
+	order := make([]int, train.Len())
+	for i := range order {
+		order[i] = i
+	}
+
+	random := rand.New(rand.NewSource(f.seed))
+
+	f.train()
+
+	for epoch := 0; epoch < f.epochs; epoch++ {
+		if f.shuffle {
+			random.Shuffle(len(order), func(i, j int) {
+				order[i], order[j] = order[j], order[i]
+			})
+		}
+
+		stats := Stats{Epoch: epoch, ValidationLoss: math.NaN()}
+
+		for batch, from := 0, 0; from < len(order); batch, from = batch+1, from+f.batchSize {
+			to := from + f.batchSize
+			if to > len(order) {
+				to = len(order)
+			}
+
+			batchLoss, err := f.trainBatch(train, order[from:to])
+			if err != nil {
+				return history, errors.Wrap(err, fmt.Sprintf("epoch %d, batch %d", epoch, batch))
+			}
+
+			stats.TrainLoss += batchLoss * float64(to-from)
+
+			for _, callback := range f.onBatchEnd {
+				callback(epoch, batch, batchLoss)
+			}
+		}
+
+		stats.TrainLoss /= float64(len(order))
+
+		if validation.Len() > 0 {
+			stats.ValidationLoss = f.evaluate(validation)
+		}
+
+		history = append(history, stats)
+
+		if t, ok := f.trainer.(TrainerWithObserve); ok {
+			if validation.Len() > 0 {
+				t.Observe(stats.ValidationLoss)
+			} else {
+				t.Observe(stats.TrainLoss)
+			}
+		}
+
+		stop := false
+		for _, callback := range f.onEpochEnd {
+			stop = callback(stats) || stop
+		}
+
+		if stop {
+			break
+		}
+	}
+
+	return history, nil
+}
+
+// trainBatch trains on samples of the indexes as a single batch and returns mean loss of the samples.
+func (f *fit) trainBatch(dataset Dataset, indexes []int) (float64, error) {
+	inputs := make([]*data.Data, len(indexes))
+	targets := make([]*data.Data, len(indexes))
+
+	for i, index := range indexes {
+		inputs[i] = dataset.Inputs[index]
+		targets[i] = dataset.Targets[index]
+	}
+
+	inputsBatch, err := data.NewBatch(inputs...)
+	if err != nil {
+		return 0, err
+	}
+
+	targetsBatch, err := data.NewBatch(targets...)
+	if err != nil {
+		return 0, err
+	}
+
+	output := f.trainer.Activate(inputsBatch, targetsBatch)
+	f.trainer.UpdateWeights()
+
+	loss := 0.0
+	volume := len(output.Data) / len(indexes)
+
+	for i := range indexes {
+		loss += f.loss.GetError(targets[i].Data, output.Data[i*volume:(i+1)*volume])
+	}
+
+	return loss / float64(len(indexes)), nil
+}
+
+func (f *fit) evaluate(dataset Dataset) float64 {
+	if n, ok := f.net.(NetWithMode); ok {
+		n.Eval()
+		defer f.train()
+	}
+	return Evaluate(f.net, f.loss, dataset)
+}
+
+// train switches the net and the trainer to train mode.
+func (f *fit) train() {
+	if n, ok := f.net.(NetWithMode); ok {
+		n.Train()
+	}
+	if t, ok := f.trainer.(NetWithMode); ok {
+		t.Train()
+	}
+}
+
+// Evaluate returns mean loss of the net over the dataset samples.
+func Evaluate(net Net, loss Loss, dataset Dataset) float64 {
+	res := 0.0
+	for i := 0; i < dataset.Len(); i++ {
+		output := net.Activate(dataset.Inputs[i])
+		res += loss.GetError(dataset.Targets[i].Data, output.Data)
+	}
+	return res / float64(dataset.Len())
+}
@@ -0,0 +1,226 @@
+package fit
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/drdreyworld/nnet/data"
+	"github.com/drdreyworld/nnet/layer/dropout"
+	"github.com/drdreyworld/nnet/layer/fc"
+	"github.com/drdreyworld/nnet/loss/regression"
+	basic_ffn "github.com/drdreyworld/nnet/net/basic-ffn"
+	"github.com/drdreyworld/nnet/optimizer/sgd"
+	"github.com/drdreyworld/nnet/schedule"
+	"github.com/drdreyworld/nnet/trainer"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func newLinearDataset(count int) Dataset {
+	random := rand.New(rand.NewSource(1))
+	dataset := Dataset{}
+	for i := 0; i < count; i++ {
+		x1, x2 := random.Float64(), random.Float64()
+		dataset.Inputs = append(dataset.Inputs, data.NewVector(x1, x2))
+		dataset.Targets = append(dataset.Targets, data.NewVector(2*x1-x2+0.5))
+	}
+	return dataset
+}
+
+func newLinearNet(t *testing.T) (Trainer, Net) {
+	net := basic_ffn.New(2, 1, 1, basic_ffn.Layers{fc.New()})
+	assert.NoError(t, net.Init())
+
+	return trainer.New(net, regression.New(), sgd.New(0.5, 0, 0)), net
+}
+
+func TestFit(t *testing.T) {
+	tr, net := newLinearNet(t)
+	dataset := newLinearDataset(50)
+
+	batches := 0
+	epochs := 0
+
+	history, err := Fit(tr, net, regression.New(), dataset,
+		Epochs(30),
+		BatchSize(8),
+		Shuffle(17),
+		ValidationSplit(0.2),
+		OnBatchEnd(func(epoch, batch int, loss float64) {
+			assert.Equal(t, epochs, epoch)
+			assert.Equal(t, batches%5, batch)
+			batches++
+		}),
+		OnEpochEnd(func(stats Stats) bool {
+			assert.Equal(t, epochs, stats.Epoch)
+			epochs++
+			return false
+		}),
+	)
+
+	assert.NoError(t, err)
+	assert.Len(t, history, 30)
+	assert.Equal(t, 30, epochs)
+	assert.Equal(t, 30*5, batches, "40 train samples in batches of 8")
+
+	first, last := history[0], history[len(history)-1]
+	assert.False(t, math.IsNaN(last.ValidationLoss))
+	assert.Less(t, last.TrainLoss, first.TrainLoss)
+	assert.Less(t, last.ValidationLoss, first.ValidationLoss)
+	assert.Less(t, last.ValidationLoss, 0.001)
+}
+
+func TestFit_Stop(t *testing.T) {
+	tr, net := newLinearNet(t)
+
+	history, err := Fit(tr, net, regression.New(), newLinearDataset(10),
+		Epochs(10),
+		OnEpochEnd(func(stats Stats) bool {
+			return stats.Epoch == 2
+		}),
+	)
+
+	assert.NoError(t, err)
+	assert.Len(t, history, 3)
+	assert.True(t, math.IsNaN(history[2].ValidationLoss), "no validation data")
+}
+
+func TestFit_Validation(t *testing.T) {
+	tr, net := newLinearNet(t)
+	validation := newLinearDataset(5)
+
+	history, err := Fit(tr, net, regression.New(), newLinearDataset(10), Validation(validation))
+
+	assert.NoError(t, err)
+	assert.Equal(t, Evaluate(net, regression.New(), validation), history[0].ValidationLoss)
+}
+
+func TestFit_Errors(t *testing.T) {
+	tr, net := newLinearNet(t)
+
+	type testCase struct {
+		dataset  Dataset
+		options  []Option
+		expected error
+	}
+
+	testCases := map[string]testCase{
+		"empty": {
+			expected: ErrorDatasetEmpty,
+		},
+		"emptyAfterSplit": {
+			dataset:  newLinearDataset(2),
+			options:  []Option{ValidationSplit(0.9)},
+			expected: ErrorDatasetEmpty,
+		},
+		"validationSplitOne": {
+			dataset:  newLinearDataset(2),
+			options:  []Option{ValidationSplit(1)},
+			expected: ErrorValidationSplit,
+		},
+		"validationSplitAboveOne": {
+			dataset:  newLinearDataset(2),
+			options:  []Option{ValidationSplit(1.5)},
+			expected: ErrorValidationSplit,
+		},
+		"validationSplitNegative": {
+			dataset:  newLinearDataset(2),
+			options:  []Option{ValidationSplit(-0.2)},
+			expected: ErrorValidationSplit,
+		},
+		"sizeMismatch": {
+			dataset:  Dataset{Inputs: newLinearDataset(2).Inputs},
+			expected: ErrorDatasetSizeMismatch,
+		},
+		"validationSizeMismatch": {
+			dataset:  newLinearDataset(2),
+			options:  []Option{Validation(Dataset{Targets: newLinearDataset(2).Targets})},
+			expected: ErrorDatasetSizeMismatch,
+		},
+		"samplesDimsMismatch": {
+			dataset: Dataset{
+				Inputs:  data.NewVectors([]float64{1, 2}, []float64{1, 2, 3}),
+				Targets: data.NewVectors([]float64{1}, []float64{1}),
+			},
+			options:  []Option{BatchSize(2)},
+			expected: data.ErrorBatchDimsMismatch,
+		},
+	}
+
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			_, err := Fit(tr, net, regression.New(), tc.dataset, tc.options...)
+			assert.Equal(t, tc.expected, errors.Cause(err))
+		})
+	}
+}
+
+// observingTrainer records metrics passed to Observe.
+type observingTrainer struct {
+	Trainer
+	metrics []float64
+}
+
+func (t *observingTrainer) Observe(metric float64) {
+	t.metrics = append(t.metrics, metric)
+}
+
+func TestFit_Observe(t *testing.T) {
+	tr, net := newLinearNet(t)
+
+	observing := &observingTrainer{Trainer: tr}
+	history, err := Fit(observing, net, regression.New(), newLinearDataset(10), Epochs(3), ValidationSplit(0.2))
+	assert.NoError(t, err)
+
+	expected := []float64{}
+	for _, stats := range history {
+		expected = append(expected, stats.ValidationLoss)
+	}
+	assert.Equal(t, expected, observing.metrics)
+
+	observing.metrics = nil
+	history, err = Fit(observing, net, regression.New(), newLinearDataset(10), Epochs(2))
+	assert.NoError(t, err)
+	assert.Equal(t, []float64{history[0].TrainLoss, history[1].TrainLoss}, observing.metrics, "train loss without validation")
+}
+
+func TestFit_ReduceOnPlateau(t *testing.T) {
+	net := basic_ffn.New(2, 1, 1, basic_ffn.Layers{fc.New()})
+	assert.NoError(t, net.Init())
+
+	// too big learning rate makes validation loss grow, so the rate is reduced
+	o := sgd.New(3, 0, 0)
+	tr := trainer.New(net, regression.New(), o, trainer.Schedule(schedule.NewReduceOnPlateau(3, 0.5, 0, 0, 0)))
+
+	history, err := Fit(tr, net, regression.New(), newLinearDataset(20), Epochs(3), ValidationSplit(0.25))
+	assert.NoError(t, err)
+	assert.Greater(t, history[1].ValidationLoss, history[0].ValidationLoss)
+
+	tr.UpdateWeights()
+	assert.Less(t, o.GetLearningRate(), 3.0)
+}
+
+func TestFit_ValidationInEvalMode(t *testing.T) {
+	net := basic_ffn.New(2, 1, 1, basic_ffn.Layers{
+		fc.New(fc.OutputSizes(8, 1, 1)),
+		dropout.New(dropout.Rate(0.5)),
+		fc.New(),
+	})
+	assert.NoError(t, net.Init())
+
+	tr := trainer.New(net, regression.New(), sgd.New(0.1, 0, 0))
+	dataset := newLinearDataset(20)
+
+	history, err := Fit(tr, net, regression.New(), dataset, Epochs(3), ValidationSplit(0.25))
+	assert.NoError(t, err)
+
+	validation := Dataset{Inputs: dataset.Inputs[15:], Targets: dataset.Targets[15:]}
+
+	trainLoss := Evaluate(net, regression.New(), validation)
+	assert.NotEqual(t, history[2].ValidationLoss, trainLoss, "net is not switched back to train mode")
+
+	net.Eval()
+	assert.Equal(t, history[2].ValidationLoss, Evaluate(net, regression.New(), validation))
+}
@@ -0,0 +1,63 @@
+package fit
+
+type Option func(f *fit)
+
+// BatchCallback is called after weights update with mean loss of the batch samples.
+type BatchCallback func(epoch, batch int, loss float64)
+
+// EpochCallback is called after every epoch, training stops when any of callbacks returns true.
+type EpochCallback func(stats Stats) (stop bool)
+
+func defaults(f *fit) {
+	f.epochs = 1
+	f.batchSize = 1
+}
+
+func Epochs(count int) Option {
+	return func(f *fit) {
+		f.epochs = count
+	}
+}
+
+func BatchSize(size int) Option {
+	return func(f *fit) {
+		if size < 1 {
+			size = 1
+		}
+		f.batchSize = size
+	}
+}
+
+// Shuffle makes training samples order random on every epoch, seed makes it reproducible.
+func Shuffle(seed int64) Option {
+	return func(f *fit) {
+		f.shuffle = true
+		f.seed = seed
+	}
+}
+
+// ValidationSplit holds out the rate of the dataset tail for validation.
+func ValidationSplit(rate float64) Option {
+	return func(f *fit) {
+		f.validationSplit = rate
+	}
+}
+
+// Validation sets separate validation dataset, it is ignored with ValidationSplit.
+func Validation(dataset Dataset) Option {
+	return func(f *fit) {
+		f.validation = dataset
+	}
+}
+
+func OnBatchEnd(callback BatchCallback) Option {
+	return func(f *fit) {
+		f.onBatchEnd = append(f.onBatchEnd, callback)
+	}
+}
+
+func OnEpochEnd(callback EpochCallback) Option {
+	return func(f *fit) {
+		f.onEpochEnd = append(f.onEpochEnd, callback)
+	}
+}
@@ -6,4 +6,5 @@ require (
 	github.com/golang/mock v1.4.3
 	github.com/pkg/errors v0.9.1
 	github.com/stretchr/testify v1.5.1
+	gopkg.in/yaml.v2 v2.2.2
 )
@@ -0,0 +1,87 @@
+package gradcheck
+
+import (
+	"math"
+
+	"github.com/drdreyworld/nnet"
+	"github.com/drdreyworld/nnet/data"
+)
+
+// BatchResult contains max absolute differences between results of the batch
+// and results of its samples activated one by one.
+type BatchResult struct {
+	Output float64
+	Result
+}
+
+func (r BatchResult) Max() float64 {
+	return math.Max(r.Output, r.Result.Max())
+}
+
+// CheckBatch activates the initialized layer by the [w, h, d, n] batch and then by every sample of it.
+// Outputs and input gradients are compared by samples, parameters gradients of the batch
+// are compared with sums of gradients of the samples. Deltas are random, see Seed option.
+// The layer is left activated by the last sample.
+func CheckBatch(layer nnet.Layer, inputs *data.Data, options ...Option) BatchResult {
+	c := &checker{layer: layer}
+	defaults(c)
+
+	for _, opt := range options {
+		opt(c)
+	}
+
+	n := inputs.GetBatchSize()
+
+	output := layer.Activate(inputs).Copy()
+	deltas := c.randomDeltas(output)
+	gradInputs := layer.Backprop(deltas).Copy()
+
+	res := BatchResult{}
+
+	l, trainable := layer.(TrainableLayer)
+
+	var gradWeights, gradBiases, sumWeights, sumBiases *data.Data
+	if trainable {
+		_, gradWeights = l.GetWeightsWithGradient()
+		_, gradBiases = l.GetBiasesWithGradient()
+		gradWeights, gradBiases = gradWeights.Copy(), gradBiases.Copy()
+		sumWeights, sumBiases = gradWeights.CopyZero(), gradBiases.CopyZero()
+	}
+
+	for s := 0; s < n; s++ {
+		sampleOutput := layer.Activate(inputs.GetSample(s))
+		res.Output = math.Max(res.Output, maxDifference(output.GetSample(s).Data, sampleOutput.Data))
+
+		sampleGradInputs := layer.Backprop(deltas.GetSample(s))
+		res.Inputs = math.Max(res.Inputs, maxDifference(gradInputs.GetSample(s).Data, sampleGradInputs.Data))
+
+		if trainable {
+			_, g := l.GetWeightsWithGradient()
+			add(sumWeights.Data, g.Data)
+
+			_, g = l.GetBiasesWithGradient()
+			add(sumBiases.Data, g.Data)
+		}
+	}
+
+	if trainable {
+		res.Weights = maxDifference(gradWeights.Data, sumWeights.Data)
+		res.Biases = maxDifference(gradBiases.Data, sumBiases.Data)
+	}
+
+	return res
+}
+
+func maxDifference(a, b []float64) float64 {
+	res := 0.0
+	for i, v := range a {
+		res = math.Max(res, math.Abs(v-b[i]))
+	}
+	return res
+}
+
+func add(sum, values []float64) {
+	for i, v := range values {
+		sum[i] += v
+	}
+}
@@ -0,0 +1,42 @@
+package gradcheck
+
+import (
+	"testing"
+
+	"github.com/drdreyworld/nnet/data"
+	"github.com/stretchr/testify/assert"
+)
+
+// meanStub averages parameters gradients over the batch instead of summing them.
+type meanStub struct {
+	layerStub
+}
+
+func (l *meanStub) Backprop(deltas *data.Data) *data.Data {
+	res := l.layerStub.Backprop(deltas)
+
+	n := float64(deltas.GetBatchSize())
+	l.gradWeights.Data[0] /= n
+	l.gradBiases.Data[0] /= n
+
+	return res
+}
+
+func TestCheckBatch(t *testing.T) {
+	layer := &layerStub{}
+	layer.InitDataSizes(2, 2, 1)
+
+	res := CheckBatch(layer, Inputs(2, 2, 1, 3, 1))
+	assert.Equal(t, 0.0, res.Output)
+	assert.Equal(t, 0.0, res.Inputs)
+	assert.InDelta(t, 0, res.Weights, 1e-12)
+	assert.InDelta(t, 0, res.Biases, 1e-12)
+
+	mean := &meanStub{}
+	mean.InitDataSizes(2, 2, 1)
+
+	res = CheckBatch(mean, Inputs(2, 2, 1, 3, 1))
+	assert.Equal(t, 0.0, res.Output)
+	assert.Greater(t, res.Biases, 0.1)
+	assert.Greater(t, res.Max(), 0.1)
+}
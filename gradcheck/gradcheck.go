@@ -0,0 +1,147 @@
+package gradcheck
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/drdreyworld/nnet"
+	"github.com/drdreyworld/nnet/data"
+)
+
+type TrainableLayer interface {
+	nnet.Layer
+	GetWeightsWithGradient() (w, g *data.Data)
+	GetBiasesWithGradient() (w, g *data.Data)
+}
+
+type Loss interface {
+	GetError(target, output []float64) float64
+	GetDeltas(target, output *data.Data) (res *data.Data)
+}
+
+// Result contains max relative errors of the analytic gradients by every tensor,
+// errors of weights and biases are zero for layers without parameters.
+type Result struct {
+	Inputs  float64
+	Weights float64
+	Biases  float64
+}
+
+func (r Result) Max() float64 {
+	return math.Max(r.Inputs, math.Max(r.Weights, r.Biases))
+}
+
+// Check compares gradients of the initialized layer computed by Backprop with central finite
+// differences of Activate. Without loss the objective is sum of outputs multiplied by random deltas.
+// Layer parameters are restored after the check and the layer is left activated by the inputs.
+func Check(layer nnet.Layer, inputs *data.Data, options ...Option) Result {
+	c := &checker{layer: layer}
+	defaults(c)
+
+	for _, opt := range options {
+		opt(c)
+	}
+
+	inputs = inputs.Copy()
+	output := layer.Activate(inputs).Copy()
+
+	if c.loss != nil {
+		c.deltas = c.loss.GetDeltas(c.target, output)
+	} else {
+		c.deltas = c.randomDeltas(output)
+	}
+
+	res := Result{}
+	gradInputs := layer.Backprop(c.deltas.Copy()).Copy()
+
+	if l, ok := layer.(TrainableLayer); ok {
+		weights, gradWeights := l.GetWeightsWithGradient()
+		biases, gradBiases := l.GetBiasesWithGradient()
+		gradWeights, gradBiases = gradWeights.Copy(), gradBiases.Copy()
+
+		res.Weights = c.compare(weights.Data, gradWeights.Data, inputs)
+		res.Biases = c.compare(biases.Data, gradBiases.Data, inputs)
+	}
+
+	res.Inputs = c.compare(inputs.Data, gradInputs.Data, inputs)
+
+	layer.Activate(inputs)
+
+	return res
+}
+
+type checker struct {
+	layer nnet.Layer
+
+	epsilon float64
+	floor   float64
+	seed    int64
+
+	loss   Loss
+	target *data.Data
+
+	deltas *data.Data
+}
+
+func (c *checker) randomDeltas(output *data.Data) *data.Data {
+	res := output.CopyZero()
+	rnd := rand.New(rand.NewSource(c.seed))
+	for i := range res.Data {
+		res.Data[i] = 2*rnd.Float64() - 1
+	}
+	return res
+}
+
+func (c *checker) objective(inputs *data.Data) float64 {
+	output := c.layer.Activate(inputs)
+
+	res := 0.0
+	if c.loss == nil {
+		for i, o := range output.Data {
+			res += o * c.deltas.Data[i]
+		}
+		return res
+	}
+
+	n := output.GetBatchSize()
+	volume := len(output.Data) / n
+	for s := 0; s < n; s++ {
+		res += c.loss.GetError(c.target.Data[s*volume:(s+1)*volume], output.Data[s*volume:(s+1)*volume])
+	}
+	return res / float64(n)
+}
+
+func (c *checker) compare(values, analytic []float64, inputs *data.Data) float64 {
+	res := 0.0
+	for i, v := range values {
+		values[i] = v + c.epsilon
+		plus := c.objective(inputs)
+
+		values[i] = v - c.epsilon
+		minus := c.objective(inputs)
+
+		values[i] = v
+
+		numeric := (plus - minus) / (2 * c.epsilon)
+		res = math.Max(res, RelativeError(analytic[i], numeric, c.floor))
+	}
+	return res
+}
+
+// RelativeError returns |a - b| / max(|a| + |b|, floor), floor keeps the error of gradients
+// equal to zero by the rounding noise of finite differences small.
+func RelativeError(a, b, floor float64) float64 {
+	return math.Abs(a-b) / math.Max(math.Abs(a)+math.Abs(b), floor)
+}
+
+// Inputs returns [w, h, d, n] batch of uniform random values in [-1, 1).
+func Inputs(w, h, d, n int, seed int64) *data.Data {
+	res := &data.Data{}
+	res.InitBatch(w, h, d, n)
+
+	rnd := rand.New(rand.NewSource(seed))
+	for i := range res.Data {
+		res.Data[i] = 2*rnd.Float64() - 1
+	}
+	return res
+}
@@ -0,0 +1,112 @@
+package gradcheck
+
+import (
+	"testing"
+
+	"github.com/drdreyworld/nnet/data"
+	"github.com/drdreyworld/nnet/loss/regression"
+	"github.com/stretchr/testify/assert"
+)
+
+// layerStub is y = w * x^2 + b for every input, broken layer doubles input gradients.
+type layerStub struct {
+	broken bool
+
+	weights, biases         *data.Data
+	gradWeights, gradBiases *data.Data
+
+	inputs *data.Data
+}
+
+func (l *layerStub) InitDataSizes(w, h, d int) (int, int, int) {
+	l.weights = data.NewVector(0.5)
+	l.biases = data.NewVector(0.1)
+	l.gradWeights = data.NewVector(0)
+	l.gradBiases = data.NewVector(0)
+	return w, h, d
+}
+
+func (l *layerStub) Activate(inputs *data.Data) *data.Data {
+	l.inputs = inputs
+	output := inputs.Copy()
+	for i, x := range inputs.Data {
+		output.Data[i] = l.weights.Data[0]*x*x + l.biases.Data[0]
+	}
+	return output
+}
+
+func (l *layerStub) Backprop(deltas *data.Data) *data.Data {
+	res := deltas.Copy()
+	l.gradWeights.Data[0], l.gradBiases.Data[0] = 0, 0
+
+	for i, d := range deltas.Data {
+		x := l.inputs.Data[i]
+		res.Data[i] = 2 * l.weights.Data[0] * x * d
+		if l.broken {
+			res.Data[i] *= 2
+		}
+
+		l.gradWeights.Data[0] += x * x * d
+		l.gradBiases.Data[0] += d
+	}
+	return res
+}
+
+func (l *layerStub) GetWeightsWithGradient() (*data.Data, *data.Data) {
+	return l.weights, l.gradWeights
+}
+
+func (l *layerStub) GetBiasesWithGradient() (*data.Data, *data.Data) {
+	return l.biases, l.gradBiases
+}
+
+func TestCheck(t *testing.T) {
+	inputs := Inputs(3, 2, 1, 2, 1)
+
+	layer := &layerStub{}
+	layer.InitDataSizes(3, 2, 1)
+
+	res := Check(layer, inputs)
+	assert.Less(t, res.Max(), 1e-7)
+
+	assert.Equal(t, []float64{0.5}, layer.weights.Data, "weights are not restored")
+	assert.Equal(t, []float64{0.1}, layer.biases.Data, "biases are not restored")
+	assert.Equal(t, inputs, layer.inputs, "layer is not activated by inputs")
+}
+
+func TestCheck_Broken(t *testing.T) {
+	layer := &layerStub{broken: true}
+	layer.InitDataSizes(3, 2, 1)
+
+	res := Check(layer, Inputs(3, 2, 1, 1, 1))
+	assert.Greater(t, res.Inputs, 0.1)
+	assert.Less(t, res.Weights, 1e-7)
+	assert.Less(t, res.Biases, 1e-7)
+}
+
+func TestCheck_WithLoss(t *testing.T) {
+	layer := &layerStub{}
+	layer.InitDataSizes(3, 1, 1)
+
+	inputs := Inputs(3, 1, 1, 2, 1)
+	target := Inputs(3, 1, 1, 2, 2)
+
+	res := Check(layer, inputs, WithLoss(regression.New(), target))
+	assert.Less(t, res.Max(), 1e-7)
+}
+
+func TestCheck_BrokenSmallGradients(t *testing.T) {
+	layer := &layerStub{broken: true}
+	layer.InitDataSizes(3, 2, 1)
+	layer.weights.Data[0] = 1e-4
+
+	res := Check(layer, Inputs(3, 2, 1, 1, 1))
+	assert.Greater(t, res.Inputs, 0.1)
+}
+
+func TestRelativeError(t *testing.T) {
+	assert.InDelta(t, 1.0/3, RelativeError(2, 4, 1e-8), 1e-15)
+	assert.InDelta(t, 1.0/3, RelativeError(1e-4, 2e-4, 1e-8), 1e-12)
+	assert.Equal(t, 1.0, RelativeError(-0.2, 0.3, 1e-8))
+	assert.InDelta(t, 0.01, RelativeError(0, 1e-10, 1e-8), 1e-15)
+}
@@ -0,0 +1,46 @@
+package gradcheck
+
+import "github.com/drdreyworld/nnet/data"
+
+const (
+	defaultEpsilon = 1e-5
+	defaultSeed    = 1
+	defaultFloor   = 1e-8
+)
+
+type Option func(c *checker)
+
+func defaults(c *checker) {
+	c.epsilon = defaultEpsilon
+	c.seed = defaultSeed
+	c.floor = defaultFloor
+}
+
+// Epsilon sets step of the finite differences.
+func Epsilon(epsilon float64) Option {
+	return func(c *checker) {
+		c.epsilon = epsilon
+	}
+}
+
+// Floor sets min denominator of the relative error, see RelativeError.
+func Floor(floor float64) Option {
+	return func(c *checker) {
+		c.floor = floor
+	}
+}
+
+// Seed sets seed of the random deltas used without loss.
+func Seed(seed int64) Option {
+	return func(c *checker) {
+		c.seed = seed
+	}
+}
+
+// WithLoss makes objective the mean loss error of the batch samples and deltas the loss deltas.
+func WithLoss(loss Loss, target *data.Data) Option {
+	return func(c *checker) {
+		c.loss = loss
+		c.target = target
+	}
+}
@@ -24,3 +24,16 @@ type LayerWithBiases interface {
 type LayerWithGradients interface {
 	GetInputGradients() *data.Data
 }
+
+// LayerWithClone is implemented by layers which can create a copy sharing
+// weights and biases with the original but having own inputs, output and gradients.
+type LayerWithClone interface {
+	Clone() Layer
+}
+
+// LayerWithMode is implemented by layers which behave differently in training
+// and inference, like dropout or batch normalization. Training is the default mode.
+type LayerWithMode interface {
+	Train()
+	Eval()
+}
@@ -0,0 +1,103 @@
+package activation
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/drdreyworld/nnet"
+	"github.com/drdreyworld/nnet/activation/elu"
+	"github.com/drdreyworld/nnet/activation/lrelu"
+	"github.com/drdreyworld/nnet/activation/plrelu"
+	"github.com/drdreyworld/nnet/activation/relu"
+	"github.com/drdreyworld/nnet/activation/selu"
+	"github.com/drdreyworld/nnet/activation/sigmoid"
+	"github.com/drdreyworld/nnet/activation/tahn"
+	"github.com/pkg/errors"
+)
+
+var (
+	ErrorFuncUnknown       = errors.New("unknown activation func")
+	ErrorFuncNotRegistered = errors.New("activation func not registered")
+)
+
+type FuncConstructor func() ActivationFunc
+
+var (
+	funcConstructors = map[string]FuncConstructor{}
+	funcNames        = map[reflect.Type]string{}
+)
+
+func init() {
+	RegisterFunc("elu", func() ActivationFunc { return elu.New(1) })
+	RegisterFunc("lrelu", func() ActivationFunc { return lrelu.New() })
+	RegisterFunc("plrelu", func() ActivationFunc { return plrelu.New(0.01) })
+	RegisterFunc("relu", func() ActivationFunc { return relu.New() })
+	RegisterFunc("selu", func() ActivationFunc { return selu.New(1) })
+	RegisterFunc("sigmoid", func() ActivationFunc { return sigmoid.New() })
+	RegisterFunc("tahn", func() ActivationFunc { return tahn.New() })
+}
+
+// RegisterFunc makes an activation func available by name for model loading.
+// Exported fields of the func (like K) are stored as its parameters.
+func RegisterFunc(name string, constructor FuncConstructor) {
+	funcConstructors[name] = constructor
+	funcNames[reflect.TypeOf(constructor())] = name
+}
+
+func NewFunc(name string) (ActivationFunc, error) {
+	constructor, ok := funcConstructors[name]
+	if !ok {
+		return nil, errors.Wrap(ErrorFuncUnknown, name)
+	}
+	return constructor(), nil
+}
+
+func GetFuncName(f ActivationFunc) (string, error) {
+	name, ok := funcNames[reflect.TypeOf(f)]
+	if !ok {
+		return "", errors.Wrap(ErrorFuncNotRegistered, fmt.Sprintf("%T", f))
+	}
+	return name, nil
+}
+
+// newFromOptions creates the layer with activation func named by the Func option,
+// the rest of options are decoded into the func itself.
+func newFromOptions(options nnet.LayerOptions) (nnet.Layer, error) {
+	if options == nil {
+		return New(nil), nil
+	}
+
+	values := map[string]interface{}{}
+	if err := options.Decode(&values); err != nil {
+		return nil, err
+	}
+
+	name := ""
+	for key, value := range values {
+		if strings.EqualFold(key, "func") {
+			name, _ = value.(string)
+			delete(values, key)
+		}
+	}
+
+	f, err := NewFunc(name)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := json.Marshal(values)
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(b))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(f); err != nil {
+		return nil, err
+	}
+
+	return New(f), nil
+}
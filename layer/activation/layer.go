@@ -2,6 +2,8 @@
 package activation
 
 import (
+	"encoding/json"
+	"github.com/drdreyworld/nnet"
 	"github.com/drdreyworld/nnet/data"
 )
 
@@ -10,6 +12,15 @@ type ActivationFunc interface {
 	Backward(v float64) float64
 }
 
+// InputsBackward is implemented by activation funcs which Backward expects inputs instead of outputs.
+type InputsBackward interface {
+	BackwardOfInputs()
+}
+
+func init() {
+	nnet.RegisterLayer("activation", newFromOptions)
+}
+
 func New(f ActivationFunc) *layer {
 	return &layer{Activation: f}
 }
@@ -22,6 +33,8 @@ type layer struct {
 
 	gradInputs *data.Data
 	Activation ActivationFunc
+
+	batchSize int
 }
 
 func (l *layer) InitDataSizes(w, h, d int) (int, int, int) {
@@ -33,12 +46,20 @@ func (l *layer) InitDataSizes(w, h, d int) (int, int, int) {
 	l.gradInputs = &data.Data{}
 	l.gradInputs.InitCube(w, h, d)
 
+	l.batchSize = 1
+
 	return w, h, d
 }
 
 func (l *layer) Activate(inputs *data.Data) *data.Data {
 	l.inputs = inputs
 
+	if n := inputs.GetBatchSize(); l.batchSize != n {
+		l.batchSize = n
+		l.output.InitBatch(l.iWidth, l.iHeight, l.iDepth, n)
+		l.gradInputs.InitBatch(l.iWidth, l.iHeight, l.iDepth, n)
+	}
+
 	for i := 0; i < len(l.inputs.Data); i++ {
 		l.output.Data[i] = l.Activation.Forward(l.inputs.Data[i])
 	}
@@ -46,12 +67,24 @@ func (l *layer) Activate(inputs *data.Data) *data.Data {
 }
 
 func (l *layer) Backprop(deltas *data.Data) *data.Data {
+	values := l.output
+	if _, ok := l.Activation.(InputsBackward); ok {
+		values = l.inputs
+	}
+
 	for i := 0; i < len(l.gradInputs.Data); i++ {
-		l.gradInputs.Data[i] = deltas.Data[i] * l.Activation.Backward(l.output.Data[i])
+		l.gradInputs.Data[i] = deltas.Data[i] * l.Activation.Backward(values.Data[i])
 	}
 	return l.gradInputs
 }
 
+// Clone returns layer with the same options and shared parameters, but with own buffers.
+func (l *layer) Clone() nnet.Layer {
+	c := *l
+	c.InitDataSizes(l.iWidth, l.iHeight, l.iDepth)
+	return &c
+}
+
 func (l *layer) GetOutput() *data.Data {
 	return l.output
 }
@@ -59,3 +92,43 @@ func (l *layer) GetOutput() *data.Data {
 func (l *layer) GetInputGradients() *data.Data {
 	return l.gradInputs
 }
+
+type state struct {
+	Func   string
+	Params json.RawMessage
+}
+
+func (l *layer) MarshalJSON() ([]byte, error) {
+	name, err := GetFuncName(l.Activation)
+	if err != nil {
+		return nil, err
+	}
+
+	params, err := json.Marshal(l.Activation)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(state{Func: name, Params: params})
+}
+
+func (l *layer) UnmarshalJSON(b []byte) error {
+	s := state{}
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+
+	f, err := NewFunc(s.Func)
+	if err != nil {
+		return err
+	}
+
+	if len(s.Params) > 0 {
+		if err := json.Unmarshal(s.Params, f); err != nil {
+			return err
+		}
+	}
+
+	l.Activation = f
+	return nil
+}
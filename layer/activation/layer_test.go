@@ -1,7 +1,9 @@
 package activation
 
 import (
+	"github.com/drdreyworld/nnet/activation/sigmoid"
 	"github.com/drdreyworld/nnet/data"
+	"github.com/drdreyworld/nnet/gradcheck"
 	"github.com/drdreyworld/nnet/layer/activation/mocks"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
@@ -62,3 +64,27 @@ func TestLayer_Backprop(t *testing.T) {
 
 	assert.Equal(t, expected, layer.GetInputGradients())
 }
+
+func TestLayer_Batch(t *testing.T) {
+	layer := New(sigmoid.New())
+	layer.InitDataSizes(2, 1, 1)
+
+	res := gradcheck.CheckBatch(layer, data.MustNewBatch(data.NewVector(1.0, 0.3), data.NewVector(-0.5, 2)))
+	assert.Equal(t, gradcheck.BatchResult{}, res)
+}
+
+func TestLayer_Gradients(t *testing.T) {
+	for _, name := range []string{"elu", "lrelu", "plrelu", "relu", "selu", "sigmoid", "tahn"} {
+		name := name
+		t.Run(name, func(t *testing.T) {
+			f, err := NewFunc(name)
+			assert.NoError(t, err)
+
+			layer := New(f)
+			layer.InitDataSizes(3, 2, 2)
+
+			res := gradcheck.Check(layer, gradcheck.Inputs(3, 2, 2, 2, 1))
+			assert.Less(t, res.Max(), 1e-7, "%+v", res)
+		})
+	}
+}
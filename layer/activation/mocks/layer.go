@@ -59,3 +59,38 @@ func (mr *MockActivationFuncMockRecorder) Backward(v interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Backward", reflect.TypeOf((*MockActivationFunc)(nil).Backward), v)
 }
+
+// MockInputsBackward is a mock of InputsBackward interface
+type MockInputsBackward struct {
+	ctrl     *gomock.Controller
+	recorder *MockInputsBackwardMockRecorder
+}
+
+// MockInputsBackwardMockRecorder is the mock recorder for MockInputsBackward
+type MockInputsBackwardMockRecorder struct {
+	mock *MockInputsBackward
+}
+
+// NewMockInputsBackward creates a new mock instance
+func NewMockInputsBackward(ctrl *gomock.Controller) *MockInputsBackward {
+	mock := &MockInputsBackward{ctrl: ctrl}
+	mock.recorder = &MockInputsBackwardMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockInputsBackward) EXPECT() *MockInputsBackwardMockRecorder {
+	return m.recorder
+}
+
+// BackwardOfInputs mocks base method
+func (m *MockInputsBackward) BackwardOfInputs() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "BackwardOfInputs")
+}
+
+// BackwardOfInputs indicates an expected call of BackwardOfInputs
+func (mr *MockInputsBackwardMockRecorder) BackwardOfInputs() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BackwardOfInputs", reflect.TypeOf((*MockInputsBackward)(nil).BackwardOfInputs))
+}
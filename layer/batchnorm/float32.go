@@ -0,0 +1,122 @@
+package batchnorm
+
+import (
+	"math"
+
+	"github.com/drdreyworld/nnet"
+	"github.com/drdreyworld/nnet/data"
+)
+
+func init() {
+	nnet.RegisterLayer("batchnorm-float32", func(options nnet.LayerOptions) (nnet.Layer, error) {
+		return &layer32{}, nil
+	})
+}
+
+// Float32 returns inference only copy of the layer normalizing by the running statistics in float32.
+func (l *layer) Float32() nnet.Layer {
+	return &layer32{
+		PerFeature:  l.PerFeature,
+		Epsilon:     l.Epsilon,
+		Weights:     l.Weights.Float32(),
+		Biases:      l.Biases.Float32(),
+		RunningMean: l.RunningMean.Float32(),
+		RunningVar:  l.RunningVar.Float32(),
+	}
+}
+
+// layer32 rounds inputs to float32 and normalizes them like batchnorm layer in eval mode.
+type layer32 struct {
+	IWidth, IHeight, IDepth int
+
+	PerFeature bool
+	Epsilon    float64
+
+	Weights *data.Data32
+	Biases  *data.Data32
+
+	RunningMean *data.Data32
+	RunningVar  *data.Data32
+
+	invStd []float32
+	output *data.Data
+
+	gradInputs *data.Data
+
+	channels  int
+	positions int
+	volume    int
+	batchSize int
+}
+
+func (l *layer32) InitDataSizes(w, h, d int) (int, int, int) {
+	channels, positions := d, w*h
+	if l.PerFeature {
+		channels, positions = w*h*d, 1
+	}
+
+	if l.Weights == nil || len(l.Weights.Data) != channels {
+		return 0, 0, 0
+	}
+
+	l.IWidth, l.IHeight, l.IDepth = w, h, d
+	l.channels, l.positions, l.volume = channels, positions, w*h*d
+
+	l.invStd = make([]float32, channels)
+	for c, v := range l.RunningVar.Data {
+		l.invStd[c] = float32(1 / math.Sqrt(float64(v)+l.Epsilon))
+	}
+
+	l.initBuffers()
+
+	return w, h, d
+}
+
+func (l *layer32) initBuffers() {
+	l.output = &data.Data{}
+	l.output.InitCube(l.IWidth, l.IHeight, l.IDepth)
+	l.gradInputs = &data.Data{}
+	l.gradInputs.InitCube(l.IWidth, l.IHeight, l.IDepth)
+	l.batchSize = 1
+}
+
+func (l *layer32) Activate(inputs *data.Data) *data.Data {
+	if n := inputs.GetBatchSize(); n != l.batchSize {
+		l.batchSize = n
+		l.output.InitBatch(l.IWidth, l.IHeight, l.IDepth, n)
+		l.gradInputs.InitBatch(l.IWidth, l.IHeight, l.IDepth, n)
+	}
+
+	for s := 0; s < l.batchSize; s++ {
+		for c := 0; c < l.channels; c++ {
+			mean, invStd := l.RunningMean.Data[c], l.invStd[c]
+			gamma, beta := l.Weights.Data[c], l.Biases.Data[c]
+
+			from := s*l.volume + c*l.positions
+			for i := from; i < from+l.positions; i++ {
+				l.output.Data[i] = float64(gamma*((float32(inputs.Data[i])-mean)*invStd) + beta)
+			}
+		}
+	}
+
+	return l.output
+}
+
+// Backprop returns zero gradients of inputs, float32 layer is used only for inference.
+// It has no trainable parameters, so training does not change it.
+func (l *layer32) Backprop(deltas *data.Data) *data.Data {
+	return l.gradInputs
+}
+
+// Clone returns layer sharing parameters, but with own buffers.
+func (l *layer32) Clone() nnet.Layer {
+	c := *l
+	if l.output != nil {
+		c.initBuffers()
+	}
+	return &c
+}
+
+func (l *layer32) GetOutput() *data.Data {
+	return l.output
+}
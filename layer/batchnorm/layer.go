@@ -0,0 +1,268 @@
+package batchnorm
+
+import (
+	"math"
+
+	"github.com/drdreyworld/nnet"
+	"github.com/drdreyworld/nnet/data"
+)
+
+func init() {
+	nnet.RegisterLayer("batchnorm", newFromOptions)
+}
+
+func New(options ...Option) *layer {
+	layer := &layer{training: true}
+	defaults(layer)
+
+	for _, opt := range options {
+		opt(layer)
+	}
+
+	return layer
+}
+
+// layer normalizes every channel over w*h positions of the batch samples, or every
+// activation over the batch samples with PerFeature. Weights are gamma and biases are beta.
+type layer struct {
+	IWidth, IHeight, IDepth int
+
+	PerFeature bool
+	Momentum   float64
+	Epsilon    float64
+
+	Weights *data.Data
+	Biases  *data.Data
+
+	RunningMean *data.Data
+	RunningVar  *data.Data
+
+	inputs *data.Data
+	output *data.Data
+	normed *data.Data
+
+	invStd []float64
+
+	gradWeights *data.Data
+	gradBiases  *data.Data
+	gradInputs  *data.Data
+
+	channels  int
+	positions int
+	volume    int
+	batchSize int
+
+	training bool
+	clone    bool
+}
+
+func (l *layer) InitDataSizes(w, h, d int) (int, int, int) {
+	l.IWidth, l.IHeight, l.IDepth = w, h, d
+
+	l.volume = w * h * d
+	l.channels, l.positions = d, w*h
+	if l.PerFeature {
+		l.channels, l.positions = l.volume, 1
+	}
+
+	if l.Weights == nil {
+		l.Weights = &data.Data{}
+		l.Biases = &data.Data{}
+		l.RunningMean = &data.Data{}
+		l.RunningVar = &data.Data{}
+	}
+
+	if len(l.Weights.Data) != l.channels {
+		l.Weights.InitVector(l.channels)
+		l.Weights.Fill(1)
+		l.Biases.InitVector(l.channels)
+
+		l.RunningMean.InitVector(l.channels)
+		l.RunningVar.InitVector(l.channels)
+		l.RunningVar.Fill(1)
+	}
+
+	l.initBuffers()
+
+	return w, h, d
+}
+
+// initBuffers allocates outputs and gradients for the initialized sizes.
+func (l *layer) initBuffers() {
+	l.batchSize = 1
+
+	l.output = &data.Data{}
+	l.output.InitCube(l.IWidth, l.IHeight, l.IDepth)
+	l.normed = &data.Data{}
+	l.normed.InitCube(l.IWidth, l.IHeight, l.IDepth)
+	l.gradInputs = &data.Data{}
+	l.gradInputs.InitCube(l.IWidth, l.IHeight, l.IDepth)
+
+	l.gradWeights = &data.Data{}
+	l.gradWeights.InitVector(l.channels)
+	l.gradBiases = &data.Data{}
+	l.gradBiases.InitVector(l.channels)
+
+	l.invStd = make([]float64, l.channels)
+}
+
+func (l *layer) initBatch(n int) {
+	if l.batchSize == n {
+		return
+	}
+
+	l.batchSize = n
+	l.output.InitBatch(l.IWidth, l.IHeight, l.IDepth, n)
+	l.normed.InitBatch(l.IWidth, l.IHeight, l.IDepth, n)
+	l.gradInputs.InitBatch(l.IWidth, l.IHeight, l.IDepth, n)
+}
+
+func (l *layer) Activate(inputs *data.Data) *data.Data {
+	l.inputs = inputs
+	l.initBatch(inputs.GetBatchSize())
+
+	count := float64(l.positions * l.batchSize)
+
+	for c := 0; c < l.channels; c++ {
+		var mean, variance float64
+
+		if !l.training {
+			mean, variance = l.RunningMean.Data[c], l.RunningVar.Data[c]
+		} else {
+			l.each(c, func(i int) {
+				mean += inputs.Data[i]
+			})
+			mean /= count
+
+			l.each(c, func(i int) {
+				variance += (inputs.Data[i] - mean) * (inputs.Data[i] - mean)
+			})
+			variance /= count
+
+			if !l.clone {
+				l.updateRunning(c, mean, variance, count)
+			}
+		}
+
+		l.invStd[c] = 1 / math.Sqrt(variance+l.Epsilon)
+
+		gamma, beta := l.Weights.Data[c], l.Biases.Data[c]
+		l.each(c, func(i int) {
+			l.normed.Data[i] = (inputs.Data[i] - mean) * l.invStd[c]
+			l.output.Data[i] = gamma*l.normed.Data[i] + beta
+		})
+	}
+
+	return l.output
+}
+
+// updateRunning keeps exponential moving averages of the batch mean and unbiased variance.
+func (l *layer) updateRunning(c int, mean, variance, count float64) {
+	if count > 1 {
+		variance *= count / (count - 1)
+	}
+
+	l.RunningMean.Data[c] = (1-l.Momentum)*l.RunningMean.Data[c] + l.Momentum*mean
+	l.RunningVar.Data[c] = (1-l.Momentum)*l.RunningVar.Data[c] + l.Momentum*variance
+}
+
+func (l *layer) Backprop(deltas *data.Data) *data.Data {
+	count := float64(l.positions * l.batchSize)
+
+	for c := 0; c < l.channels; c++ {
+		sumDeltas, sumDeltasNormed := 0.0, 0.0
+		l.each(c, func(i int) {
+			sumDeltas += deltas.Data[i]
+			sumDeltasNormed += deltas.Data[i] * l.normed.Data[i]
+		})
+
+		l.gradWeights.Data[c] = sumDeltasNormed
+		l.gradBiases.Data[c] = sumDeltas
+
+		k := l.Weights.Data[c] * l.invStd[c]
+
+		if !l.training {
+			l.each(c, func(i int) {
+				l.gradInputs.Data[i] = k * deltas.Data[i]
+			})
+			continue
+		}
+
+		l.each(c, func(i int) {
+			l.gradInputs.Data[i] = k * (deltas.Data[i] - (sumDeltas+l.normed.Data[i]*sumDeltasNormed)/count)
+		})
+	}
+
+	return l.gradInputs
+}
+
+// each calls f with indexes of the channel values of every batch sample.
+func (l *layer) each(c int, f func(i int)) {
+	for s := 0; s < l.batchSize; s++ {
+		offset := s*l.volume + c*l.positions
+		for p := 0; p < l.positions; p++ {
+			f(offset + p)
+		}
+	}
+}
+
+// Train makes layer normalize by the batch statistics and update the running ones.
+func (l *layer) Train() {
+	l.training = true
+}
+
+// Eval makes layer normalize by the running statistics.
+func (l *layer) Eval() {
+	l.training = false
+}
+
+// Clone returns layer with the same options and shared parameters and running statistics,
+// but with own buffers. Clones do not update running statistics and read them only in eval mode,
+// so they are safe for concurrent training. In the parallel trainer running statistics are updated
+// only by the net, so by the statistics of its part of the batch.
+// Parameters of not initialized layer are created empty, so they are initialized once
+// by the first of the layers initialized.
+func (l *layer) Clone() nnet.Layer {
+	if l.Weights == nil {
+		l.Weights = &data.Data{}
+		l.Biases = &data.Data{}
+		l.RunningMean = &data.Data{}
+		l.RunningVar = &data.Data{}
+	}
+
+	c := *l
+	c.clone = true
+	if l.output != nil {
+		c.initBuffers()
+	}
+	return &c
+}
+
+// GetStatistics returns running mean and variance.
+func (l *layer) GetStatistics() []*data.Data {
+	return []*data.Data{l.RunningMean, l.RunningVar}
+}
+
+func (l *layer) GetOutput() *data.Data {
+	return l.output
+}
+
+func (l *layer) GetWeights() *data.Data {
+	return l.Weights
+}
+
+func (l *layer) GetBiases() *data.Data {
+	return l.Biases
+}
+
+func (l *layer) GetWeightsWithGradient() (*data.Data, *data.Data) {
+	return l.Weights, l.gradWeights
+}
+
+func (l *layer) GetBiasesWithGradient() (*data.Data, *data.Data) {
+	return l.Biases, l.gradBiases
+}
+
+func (l *layer) GetInputGradients() *data.Data {
+	return l.gradInputs
+}
@@ -0,0 +1,106 @@
+package batchnorm
+
+import (
+	"math"
+	"testing"
+
+	"github.com/drdreyworld/nnet/data"
+	"github.com/drdreyworld/nnet/gradcheck"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLayer_Activate(t *testing.T) {
+	layer := New(Momentum(0.5))
+	layer.InitDataSizes(2, 1, 2)
+
+	inputs := data.MustNewBatch(
+		&data.Data{Dims: []int{2, 1, 2}, Data: []float64{1, 3, 10, 10}},
+		&data.Data{Dims: []int{2, 1, 2}, Data: []float64{5, 7, 20, 20}},
+	)
+
+	layer.Weights.Data = []float64{2, 1}
+	layer.Biases.Data = []float64{1, 0}
+
+	output := layer.Activate(inputs)
+
+	// channel 1: mean 4, variance 5, channel 2: mean 15, variance 25
+	k1, k2 := 2/math.Sqrt(5+defaultEpsilon), 1/math.Sqrt(25+defaultEpsilon)
+	assert.InDeltaSlice(t, []float64{
+		1 - 3*k1, 1 - k1, -5 * k2, -5 * k2,
+		1 + k1, 1 + 3*k1, 5 * k2, 5 * k2,
+	}, output.Data, 1e-12)
+
+	assert.InDeltaSlice(t, []float64{2, 7.5}, layer.RunningMean.Data, 1e-12)
+	assert.InDeltaSlice(t, []float64{0.5 + 0.5*20/3, 0.5 + 0.5*100/3}, layer.RunningVar.Data, 1e-12)
+}
+
+func TestLayer_Eval(t *testing.T) {
+	layer := New(PerFeature())
+	layer.InitDataSizes(2, 1, 1)
+
+	layer.RunningMean.Data = []float64{1, 2}
+	layer.RunningVar.Data = []float64{4, 9}
+	layer.Weights.Data = []float64{2, 3}
+	layer.Biases.Data = []float64{0.5, 0}
+	layer.Epsilon = 0
+
+	layer.Eval()
+	output := layer.Activate(data.NewVector(3, -1))
+
+	assert.InDeltaSlice(t, []float64{2.5, -3}, output.Data, 1e-12)
+	assert.Equal(t, []float64{1, 2}, layer.RunningMean.Data, "running mean changed in eval mode")
+	assert.Equal(t, []float64{4, 9}, layer.RunningVar.Data, "running variance changed in eval mode")
+}
+
+func TestLayer_Gradients(t *testing.T) {
+	type testCase struct {
+		options    []Option
+		iw, ih, id int
+		n          int
+		eval       bool
+	}
+	testCases := map[string]testCase{
+		"Channels":        {iw: 3, ih: 2, id: 2, n: 1},
+		"ChannelsBatch":   {iw: 2, ih: 2, id: 3, n: 3},
+		"PerFeatureBatch": {options: []Option{PerFeature()}, iw: 3, ih: 1, id: 1, n: 4},
+		"ChannelsEval":    {iw: 2, ih: 2, id: 2, n: 2, eval: true},
+		"PerFeatureEval":  {options: []Option{PerFeature()}, iw: 3, ih: 1, id: 1, n: 1, eval: true},
+	}
+
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			layer := New(tc.options...)
+			layer.InitDataSizes(tc.iw, tc.ih, tc.id)
+
+			layer.Weights.FillRandom(0.5, 1.5)
+			layer.Biases.FillRandom(-1, 1)
+			layer.RunningMean.FillRandom(-1, 1)
+			layer.RunningVar.FillRandom(0.5, 1.5)
+
+			if tc.eval {
+				layer.Eval()
+			}
+
+			// input gradients of normalization sum to zero, some of them are close to zero and compared by absolute error
+			res := gradcheck.Check(layer, gradcheck.Inputs(tc.iw, tc.ih, tc.id, tc.n, 1), gradcheck.Floor(1e-3))
+			assert.Less(t, res.Max(), 1e-6, "%+v", res)
+		})
+	}
+}
+
+func TestLayer_Clone(t *testing.T) {
+	original := New()
+	original.InitDataSizes(2, 2, 1)
+
+	clone := original.Clone().(*layer)
+	assert.Same(t, original.Weights, clone.Weights)
+	assert.Same(t, original.RunningMean, clone.RunningMean)
+	assert.NotSame(t, original.output, clone.output)
+
+	clone.Activate(gradcheck.Inputs(2, 2, 1, 2, 1))
+	assert.Equal(t, []float64{0}, original.RunningMean.Data, "clone updated running mean")
+
+	original.Activate(gradcheck.Inputs(2, 2, 1, 2, 1))
+	assert.NotEqual(t, []float64{0}, original.RunningMean.Data)
+}
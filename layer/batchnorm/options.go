@@ -0,0 +1,63 @@
+package batchnorm
+
+import "github.com/drdreyworld/nnet"
+
+type Option func(layer *layer)
+
+const (
+	defaultMomentum = 0.1
+	defaultEpsilon  = 1e-5
+)
+
+func defaults(layer *layer) {
+	layer.Momentum = defaultMomentum
+	layer.Epsilon = defaultEpsilon
+}
+
+// PerFeature normalizes every activation separately, it is used after fc layers.
+func PerFeature() Option {
+	return func(layer *layer) {
+		layer.PerFeature = true
+	}
+}
+
+// Momentum sets weight of the batch statistics in the running ones.
+func Momentum(momentum float64) Option {
+	return func(layer *layer) {
+		layer.Momentum = momentum
+	}
+}
+
+func Epsilon(epsilon float64) Option {
+	return func(layer *layer) {
+		layer.Epsilon = epsilon
+	}
+}
+
+type config struct {
+	PerFeature *bool
+	Momentum   *float64
+	Epsilon    *float64
+}
+
+func newFromOptions(options nnet.LayerOptions) (nnet.Layer, error) {
+	c := config{}
+	if options != nil {
+		if err := options.Decode(&c); err != nil {
+			return nil, err
+		}
+	}
+
+	layer := New()
+	if c.PerFeature != nil {
+		layer.PerFeature = *c.PerFeature
+	}
+	if c.Momentum != nil {
+		Momentum(*c.Momentum)(layer)
+	}
+	if c.Epsilon != nil {
+		Epsilon(*c.Epsilon)(layer)
+	}
+
+	return layer, nil
+}
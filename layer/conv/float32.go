@@ -0,0 +1,154 @@
+package conv
+
+import (
+	"github.com/drdreyworld/nnet"
+	"github.com/drdreyworld/nnet/data"
+	"github.com/drdreyworld/nnet/linalg"
+)
+
+func init() {
+	nnet.RegisterLayer("conv-float32", func(options nnet.LayerOptions) (nnet.Layer, error) {
+		return &layer32{Conv: New()}, nil
+	})
+}
+
+// Float32 returns inference only copy of the layer with weights and computations in float32.
+func (l *layer) Float32() nnet.Layer {
+	return &layer32{
+		Conv:    l.options(),
+		Weights: l.Weights.Float32(),
+		Biases:  l.Biases.Float32(),
+	}
+}
+
+// layer32 rounds inputs to float32 and multiplies float32 filters by input columns.
+type layer32 struct {
+	// Conv holds options and sizes of the convolution, its weights are not used
+	Conv *layer
+
+	Weights *data.Data32
+	Biases  *data.Data32
+
+	inputs        []float32
+	columns       []float32
+	packedWeights []float32
+	outputs       []float32
+	output        *data.Data
+
+	gradInputs *data.Data
+
+	batchSize int
+}
+
+func (l *layer32) InitDataSizes(iw, ih, id int) (int, int, int) {
+	c := l.Conv
+
+	w, h, d := c.initSizes(iw, ih, id)
+	if w < 1 || h < 1 || d < 1 {
+		return w, h, d
+	}
+
+	if l.Weights == nil || len(l.Weights.Data) != c.FCount*c.wCube {
+		return 0, 0, 0
+	}
+
+	c.initColIndexes()
+
+	l.packedWeights = make([]float32, len(l.Weights.Data))
+	for f := 0; f < c.FCount; f++ {
+		for i, index := range c.weightIndexes {
+			l.packedWeights[f*c.wCube+i] = l.Weights.Data[f*c.wCube+index]
+		}
+	}
+
+	l.initBuffers()
+
+	return w, h, d
+}
+
+func (l *layer32) initBuffers() {
+	c := l.Conv
+
+	l.inputs = make([]float32, c.iCube)
+	l.columns = make([]float32, c.oSquare*c.wCube)
+	l.outputs = make([]float32, c.oCube)
+
+	l.output = &data.Data{}
+	l.output.InitCube(c.oWidth, c.oHeight, c.oDepth)
+	l.gradInputs = &data.Data{}
+	l.gradInputs.InitCube(c.iWidth, c.iHeight, c.iDepth)
+	l.batchSize = 1
+}
+
+func (l *layer32) Activate(inputs *data.Data) *data.Data {
+	c := l.Conv
+
+	if n := inputs.GetBatchSize(); n != l.batchSize {
+		l.batchSize = n
+		l.output.InitBatch(c.oWidth, c.oHeight, c.oDepth, n)
+		l.gradInputs.InitBatch(c.iWidth, c.iHeight, c.iDepth, n)
+	}
+
+	k := c.wCube
+
+	for s := 0; s < l.batchSize; s++ {
+		for i, v := range inputs.Data[s*c.iCube : (s+1)*c.iCube] {
+			l.inputs[i] = float32(v)
+		}
+
+		for filterIndex := 0; filterIndex < c.FCount; filterIndex++ {
+			filterOutputs := l.outputs[filterIndex*c.oSquare : (filterIndex+1)*c.oSquare]
+			for p := range filterOutputs {
+				filterOutputs[p] = l.Biases.Data[filterIndex]
+			}
+		}
+
+		for g := 0; g < c.Groups; g++ {
+			groupInputs := l.inputs[g*c.FDepth*c.iSquare:]
+			for p := 0; p < c.oSquare; p++ {
+				for i, index := range c.colIndexes[p*k : (p+1)*k] {
+					if index < 0 {
+						l.columns[i*c.oSquare+p] = 0
+					} else {
+						l.columns[i*c.oSquare+p] = groupInputs[index]
+					}
+				}
+			}
+
+			linalg.MulAdd32(
+				l.outputs[g*c.groupFilters*c.oSquare:(g+1)*c.groupFilters*c.oSquare],
+				l.packedWeights[g*c.groupFilters*k:(g+1)*c.groupFilters*k],
+				l.columns,
+				c.groupFilters, c.oSquare, k,
+			)
+		}
+
+		output := l.output.Data[s*c.oCube : (s+1)*c.oCube]
+		for i, v := range l.outputs {
+			output[i] = float64(v)
+		}
+	}
+
+	return l.output
+}
+
+// Backprop returns zero gradients of inputs, float32 layer is used only for inference.
+// It has no trainable parameters, so training does not change it.
+func (l *layer32) Backprop(deltas *data.Data) *data.Data {
+	return l.gradInputs
+}
+
+// Clone returns layer sharing weights and index tables, but with own buffers.
+func (l *layer32) Clone() nnet.Layer {
+	c := *l
+	conv := *l.Conv
+	c.Conv = &conv
+	if l.output != nil {
+		c.initBuffers()
+	}
+	return &c
+}
+
+func (l *layer32) GetOutput() *data.Data {
+	return l.output
+}
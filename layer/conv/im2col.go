@@ -0,0 +1,156 @@
+package conv
+
+import "github.com/drdreyworld/nnet/linalg"
+
+// initColIndexes builds index tables of the im2col backend. Taps of an output pixel are
+// ordered by filter y, x and input channel, so dot products sum in the same order
+// as the nested loops and results of both backends are identical.
+// Tables depend only on sizes, so clones share them.
+func (l *layer) initColIndexes() {
+	k := l.wCube
+
+	l.colIndexes = make([]int, l.oSquare*k)
+	l.weightIndexes = make([]int, k)
+
+	for fy := 0; fy < l.FHeight; fy++ {
+		for fx := 0; fx < l.FWidth; fx++ {
+			for iz := 0; iz < l.FDepth; iz++ {
+				l.weightIndexes[(fy*l.FWidth+fx)*l.FDepth+iz] = iz*l.wSquare + fy*l.FWidth + fx
+			}
+		}
+	}
+
+	p := 0
+	for oy, initInputY := 0, -l.FPaddingTop; oy < l.oHeight; oy, initInputY = oy+1, initInputY+l.FStrideY {
+		for ox, initInputX := 0, -l.FPaddingLeft; ox < l.oWidth; ox, initInputX = ox+1, initInputX+l.FStrideX {
+			column := l.colIndexes[p*k : (p+1)*k]
+
+			for fy, iy := 0, initInputY; fy < l.FHeight; fy, iy = fy+1, iy+l.DilationY {
+				for fx, ix := 0, initInputX; fx < l.FWidth; fx, ix = fx+1, ix+l.DilationX {
+					for iz := 0; iz < l.FDepth; iz++ {
+						index := -1
+						if iy > -1 && iy < l.iHeight && ix > -1 && ix < l.iWidth {
+							index = iz*l.iSquare + iy*l.iWidth + ix
+						}
+						column[(fy*l.FWidth+fx)*l.FDepth+iz] = index
+					}
+				}
+			}
+			p++
+		}
+	}
+}
+
+// packWeights copies weights of every filter in order of the taps. Activate packs weights once
+// per step and Backprop uses them, so they are the weights of the activation.
+func (l *layer) packWeights() {
+	k := l.wCube
+	for f := 0; f < l.FCount; f++ {
+		weights := l.Weights.Data[f*k : (f+1)*k]
+		packed := l.packedWeights[f*k : (f+1)*k]
+
+		for i, index := range l.weightIndexes {
+			packed[i] = weights[index]
+		}
+	}
+}
+
+// im2col copies inputs of the group into matrix of taps by output pixels, padding is filled by zeros.
+func (l *layer) im2col(inputs []float64) {
+	k := l.wCube
+	for p := 0; p < l.oSquare; p++ {
+		for i, index := range l.colIndexes[p*k : (p+1)*k] {
+			if index < 0 {
+				l.columns[i*l.oSquare+p] = 0
+			} else {
+				l.columns[i*l.oSquare+p] = inputs[index]
+			}
+		}
+	}
+}
+
+// im2row copies inputs of the group into matrix of output pixels by taps, padding is filled by zeros.
+func (l *layer) im2row(inputs []float64) {
+	for i, index := range l.colIndexes {
+		if index < 0 {
+			l.columns[i] = 0
+		} else {
+			l.columns[i] = inputs[index]
+		}
+	}
+}
+
+// activateSampleIm2col sets outputs of every filter to its bias and adds packed weights
+// of the group multiplied by the group columns.
+func (l *layer) activateSampleIm2col(inputs, output []float64) {
+	k := l.wCube
+
+	for filterIndex := 0; filterIndex < l.FCount; filterIndex++ {
+		filterOutput := output[filterIndex*l.oSquare : (filterIndex+1)*l.oSquare]
+		for p := range filterOutput {
+			filterOutput[p] = l.Biases.Data[filterIndex]
+		}
+	}
+
+	for g := 0; g < l.Groups; g++ {
+		l.im2col(inputs[g*l.FDepth*l.iSquare:])
+
+		linalg.MulAdd(
+			output[g*l.groupFilters*l.oSquare:(g+1)*l.groupFilters*l.oSquare],
+			l.packedWeights[g*l.groupFilters*k:(g+1)*l.groupFilters*k],
+			l.columns,
+			l.groupFilters, l.oSquare, k,
+		)
+	}
+}
+
+// backpropSampleIm2col accumulates weights gradients in order of the taps,
+// they are unpacked by unpackGradWeights after the batch.
+func (l *layer) backpropSampleIm2col(inputs, deltas, gradInputs []float64) {
+	k := l.wCube
+
+	for g := 0; g < l.Groups; g++ {
+		l.im2row(inputs[g*l.FDepth*l.iSquare:])
+
+		linalg.MulAdd(
+			l.packedGradWeights[g*l.groupFilters*k:(g+1)*l.groupFilters*k],
+			deltas[g*l.groupFilters*l.oSquare:(g+1)*l.groupFilters*l.oSquare],
+			l.columns,
+			l.groupFilters, k, l.oSquare,
+		)
+	}
+
+	// input gradients are scattered over overlapping taps, they are added filter by filter
+	// to keep the summation order of the nested loops
+	for filterIndex := 0; filterIndex < l.FCount; filterIndex++ {
+		groupGradInputs := gradInputs[filterIndex/l.groupFilters*l.FDepth*l.iSquare:]
+
+		weights := l.packedWeights[filterIndex*k : (filterIndex+1)*k]
+		filterDeltas := deltas[filterIndex*l.oSquare : (filterIndex+1)*l.oSquare]
+
+		for p, delta := range filterDeltas {
+			indexes := l.colIndexes[p*k : (p+1)*k]
+			indexes = indexes[:len(weights)]
+
+			for i, index := range indexes {
+				if index > -1 {
+					groupGradInputs[index] += weights[i] * delta
+				}
+			}
+
+			l.gradBiases.Data[filterIndex] += delta
+		}
+	}
+}
+
+func (l *layer) unpackGradWeights() {
+	k := l.wCube
+	for f := 0; f < l.FCount; f++ {
+		gradWeights := l.gradWeights.Data[f*k : (f+1)*k]
+		packed := l.packedGradWeights[f*k : (f+1)*k]
+
+		for i, index := range l.weightIndexes {
+			gradWeights[index] = packed[i]
+		}
+	}
+}
@@ -1,9 +1,16 @@
 package conv
 
 import (
+	"encoding/json"
+
+	"github.com/drdreyworld/nnet"
 	"github.com/drdreyworld/nnet/data"
 )
 
+func init() {
+	nnet.RegisterLayer("conv", newFromOptions)
+}
+
 func New(options ...Option) *layer {
 	layer := &layer{}
 	defaults(layer)
@@ -20,9 +27,26 @@ type layer struct {
 	FWidth, FHeight, FDepth int
 	oWidth, oHeight, oDepth int
 
-	FCount   int
-	FPadding int
-	FStride  int
+	FCount int
+
+	// Groups splits input channels and filters into groups, filters of a group are connected
+	// only to the input channels of the group, so FDepth is input depth divided by groups
+	Groups int
+
+	FStrideX, FStrideY int
+
+	FPaddingTop, FPaddingBottom int
+	FPaddingLeft, FPaddingRight int
+
+	// SamePadding makes output size equal to input size divided by stride,
+	// the padding is computed by InitDataSizes and the odd pixel is added to bottom and right
+	SamePadding bool
+
+	DilationX, DilationY int
+
+	// Loops makes layer use nested loops instead of im2col and matrix multiply,
+	// both backends give identical results
+	Loops bool
 
 	Weights *data.Data
 	Biases  *data.Data
@@ -38,16 +62,26 @@ type layer struct {
 	oSquare int
 	wSquare int
 	wCube   int
-}
 
-func (l *layer) InitDataSizes(iw, ih, id int) (int, int, int) {
-	l.iWidth, l.iHeight, l.iDepth = iw, ih, id
+	iCube int
+	oCube int
 
-	l.oWidth = (iw-l.FWidth+2*l.FPadding)/l.FStride + 1
-	l.oHeight = (ih-l.FHeight+2*l.FPadding)/l.FStride + 1
+	groupFilters int
 
-	l.oDepth = l.FCount
-	l.FDepth = id
+	// buffers of the im2col backend
+	colIndexes        []int
+	weightIndexes     []int
+	columns           []float64
+	packedWeights     []float64
+	packedGradWeights []float64
+
+	batchSize int
+}
+
+func (l *layer) InitDataSizes(iw, ih, id int) (int, int, int) {
+	if w, h, d := l.initSizes(iw, ih, id); w < 1 || h < 1 || d < 1 {
+		return w, h, d
+	}
 
 	if l.Weights == nil {
 		l.Weights = &data.Data{}
@@ -60,6 +94,14 @@ func (l *layer) InitDataSizes(iw, ih, id int) (int, int, int) {
 		l.Biases.Fill(0.1)
 	}
 
+	l.initColIndexes()
+	l.initBuffers()
+
+	return l.oWidth, l.oHeight, l.oDepth
+}
+
+// initBuffers allocates outputs, gradients and im2col buffers for the computed sizes.
+func (l *layer) initBuffers() {
 	l.output = &data.Data{}
 	l.output.InitCube(l.oWidth, l.oHeight, l.oDepth)
 
@@ -72,35 +114,108 @@ func (l *layer) InitDataSizes(iw, ih, id int) (int, int, int) {
 	l.gradInputs = &data.Data{}
 	l.gradInputs.InitCube(l.iWidth, l.iHeight, l.iDepth)
 
+	l.columns = make([]float64, l.oSquare*l.wCube)
+	l.packedWeights = make([]float64, l.FCount*l.wCube)
+	l.packedGradWeights = make([]float64, l.FCount*l.wCube)
+
+	l.batchSize = 1
+}
+
+// initSizes computes output and filter sizes without allocation of weights and buffers.
+func (l *layer) initSizes(iw, ih, id int) (int, int, int) {
+	l.FStrideX, l.FStrideY = atLeastOne(l.FStrideX), atLeastOne(l.FStrideY)
+	l.DilationX, l.DilationY = atLeastOne(l.DilationX), atLeastOne(l.DilationY)
+
+	l.iWidth, l.iHeight, l.iDepth = iw, ih, id
+
+	// size of the filter with dilation gaps
+	fw := (l.FWidth-1)*l.DilationX + 1
+	fh := (l.FHeight-1)*l.DilationY + 1
+
+	if l.SamePadding {
+		l.FPaddingLeft, l.FPaddingRight = samePadding(iw, fw, l.FStrideX)
+		l.FPaddingTop, l.FPaddingBottom = samePadding(ih, fh, l.FStrideY)
+	}
+
+	l.oWidth = (iw+l.FPaddingLeft+l.FPaddingRight-fw)/l.FStrideX + 1
+	l.oHeight = (ih+l.FPaddingTop+l.FPaddingBottom-fh)/l.FStrideY + 1
+
+	l.Groups = atLeastOne(l.Groups)
+	if id%l.Groups != 0 || l.FCount%l.Groups != 0 {
+		return 0, 0, 0
+	}
+
+	l.oDepth = l.FCount
+	l.FDepth = id / l.Groups
+
 	l.iSquare = l.iWidth * l.iHeight
 	l.oSquare = l.oWidth * l.oHeight
 	l.wSquare = l.FWidth * l.FHeight
 	l.wCube = l.FDepth * l.wSquare
 
+	l.iCube = l.iDepth * l.iSquare
+	l.oCube = l.oDepth * l.oSquare
+
+	l.groupFilters = l.FCount / l.Groups
+
 	return l.oWidth, l.oHeight, l.oDepth
 }
 
+func (l *layer) initBatch(n int) {
+	if l.batchSize == n {
+		return
+	}
+
+	l.batchSize = n
+	l.output.InitBatch(l.oWidth, l.oHeight, l.oDepth, n)
+	l.gradInputs.InitBatch(l.iWidth, l.iHeight, l.iDepth, n)
+}
+
 func (l *layer) Activate(inputs *data.Data) *data.Data {
 	l.inputs = inputs
+	l.initBatch(inputs.GetBatchSize())
+
+	if !l.Loops {
+		l.packWeights()
+
+		for s := 0; s < l.batchSize; s++ {
+			l.activateSampleIm2col(
+				l.inputs.Data[s*l.iCube:(s+1)*l.iCube],
+				l.output.Data[s*l.oCube:(s+1)*l.oCube],
+			)
+		}
+		return l.output
+	}
+
+	for s := 0; s < l.batchSize; s++ {
+		l.activateSample(
+			l.inputs.Data[s*l.iCube:(s+1)*l.iCube],
+			l.output.Data[s*l.oCube:(s+1)*l.oCube],
+		)
+	}
+	return l.output
+}
 
+func (l *layer) activateSample(inputs, output []float64) {
 	for filterIndex := 0; filterIndex < l.FCount; filterIndex++ {
-		filterOutputOffset := filterIndex * l.oSquare // can be i = 0..len(output.Data)
+		filterOutputOffset := filterIndex * l.oSquare // can be i = 0..len(output)
 		filterWeightsOffset := filterIndex * l.wCube
+		groupInputOffset := filterIndex / l.groupFilters * l.FDepth * l.iSquare
 
-		for oy, initInputY := 0, -l.FPadding; oy < l.oHeight; oy, initInputY = oy+1, initInputY+l.FStride {
-			for ox, initInputX := 0, -l.FPadding; ox < l.oWidth; ox, initInputX = ox+1, initInputX+l.FStride {
+		for oy, initInputY := 0, -l.FPaddingTop; oy < l.oHeight; oy, initInputY = oy+1, initInputY+l.FStrideY {
+			for ox, initInputX := 0, -l.FPaddingLeft; ox < l.oWidth; ox, initInputX = ox+1, initInputX+l.FStrideX {
 
-				l.output.Data[filterOutputOffset] = l.Biases.Data[filterIndex]
+				output[filterOutputOffset] = l.Biases.Data[filterIndex]
 
-				for fy, iy := 0, initInputY; fy < l.FHeight; fy, iy = fy+1, iy+1 {
-					for fx, ix := 0, initInputX; fx < l.FWidth; fx, ix = fx+1, ix+1 {
-						for iz := 0; iz < l.iDepth; iz++ {
+				for fy, iy := 0, initInputY; fy < l.FHeight; fy, iy = fy+1, iy+l.DilationY {
+					for fx, ix := 0, initInputX; fx < l.FWidth; fx, ix = fx+1, ix+l.DilationX {
+						for iz := 0; iz < l.FDepth; iz++ {
 
 							if iy > -1 && iy < l.iHeight && ix > -1 && ix < l.iWidth {
-								inXYZ := iz*l.iSquare + iy*l.iWidth + ix
+								inXYZ := groupInputOffset + iz*l.iSquare + iy*l.iWidth + ix
 								wtXYZ := filterWeightsOffset + iz*l.wSquare + fy*l.FWidth + fx
 
-								l.output.Data[filterOutputOffset] += l.inputs.Data[inXYZ] * l.Weights.Data[wtXYZ]
+								output[filterOutputOffset] += inputs[inXYZ] * l.Weights.Data[wtXYZ]
 							}
 						}
 					}
@@ -110,32 +225,63 @@ func (l *layer) Activate(inputs *data.Data) *data.Data {
 			}
 		}
 	}
-	return l.output
 }
 
+// Backprop sums weights and biases gradients over the batch samples,
+// the im2col backend uses weights packed by the last Activate.
 func (l *layer) Backprop(deltas *data.Data) *data.Data {
 	l.gradInputs.Reset()
 	l.gradWeights.Reset()
 	l.gradBiases.Reset()
 
+	if !l.Loops {
+		for i := range l.packedGradWeights {
+			l.packedGradWeights[i] = 0
+		}
+
+		for s := 0; s < l.batchSize; s++ {
+			l.backpropSampleIm2col(
+				l.inputs.Data[s*l.iCube:(s+1)*l.iCube],
+				deltas.Data[s*l.oCube:(s+1)*l.oCube],
+				l.gradInputs.Data[s*l.iCube:(s+1)*l.iCube],
+			)
+		}
+
+		l.unpackGradWeights()
+		return l.gradInputs
+	}
+
+	for s := 0; s < l.batchSize; s++ {
+		l.backpropSample(
+			l.inputs.Data[s*l.iCube:(s+1)*l.iCube],
+			deltas.Data[s*l.oCube:(s+1)*l.oCube],
+			l.gradInputs.Data[s*l.iCube:(s+1)*l.iCube],
+		)
+	}
+
+	return l.gradInputs
+}
+
+func (l *layer) backpropSample(inputs, deltas, gradInputs []float64) {
 	for filterIndex := 0; filterIndex < l.FCount; filterIndex++ {
 		filterOutputOffset := filterIndex * l.oSquare
 		filterWeightsOffset := filterIndex * l.wCube
+		groupInputOffset := filterIndex / l.groupFilters * l.FDepth * l.iSquare
 
-		for oy, initInputY := 0, -l.FPadding; oy < l.oHeight; oy, initInputY = oy+1, initInputY+l.FStride {
-			for ox, initInputX := 0, -l.FPadding; ox < l.oWidth; ox, initInputX = ox+1, initInputX+l.FStride {
+		for oy, initInputY := 0, -l.FPaddingTop; oy < l.oHeight; oy, initInputY = oy+1, initInputY+l.FStrideY {
+			for ox, initInputX := 0, -l.FPaddingLeft; ox < l.oWidth; ox, initInputX = ox+1, initInputX+l.FStrideX {
 
-				delta := deltas.Data[filterOutputOffset]
+				delta := deltas[filterOutputOffset]
 
-				for fy, iy := 0, initInputY; fy < l.FHeight; fy, iy = fy+1, iy+1 {
-					for fx, ix := 0, initInputX; fx < l.FWidth; fx, ix = fx+1, ix+1 {
-						for iz := 0; iz < l.iDepth; iz++ {
+				for fy, iy := 0, initInputY; fy < l.FHeight; fy, iy = fy+1, iy+l.DilationY {
+					for fx, ix := 0, initInputX; fx < l.FWidth; fx, ix = fx+1, ix+l.DilationX {
+						for iz := 0; iz < l.FDepth; iz++ {
 							if iy > -1 && iy < l.iHeight && ix > -1 && ix < l.iWidth {
-								inXYZ := iz*l.iSquare + iy*l.iWidth + ix
+								inXYZ := groupInputOffset + iz*l.iSquare + iy*l.iWidth + ix
 								wtXYZ := filterWeightsOffset + iz*l.wSquare + fy*l.FWidth + fx
 
-								l.gradInputs.Data[inXYZ] += l.Weights.Data[wtXYZ] * delta
-								l.gradWeights.Data[wtXYZ] += l.inputs.Data[inXYZ] * delta
+								gradInputs[inXYZ] += l.Weights.Data[wtXYZ] * delta
+								l.gradWeights.Data[wtXYZ] += inputs[inXYZ] * delta
 							}
 						}
 					}
@@ -146,14 +292,89 @@ func (l *layer) Backprop(deltas *data.Data) *data.Data {
 			}
 		}
 	}
+}
 
-	return l.gradInputs
+// samePadding returns padding before and after the input, so the output size is input size divided by stride.
+func samePadding(size, filter, stride int) (int, int) {
+	out := (size + stride - 1) / stride
+
+	total := (out-1)*stride + filter - size
+	if total < 0 {
+		total = 0
+	}
+	return total / 2, total - total/2
+}
+
+func atLeastOne(v int) int {
+	if v < 1 {
+		return 1
+	}
+	return v
 }
 
 func (l *layer) GetWeights() *data.Data {
 	return l.Weights
 }
 
+// Clone returns layer with the same options, shared parameters and index tables, but with own buffers.
+// Parameters of not initialized layer are created empty, so they are initialized once
+// by the first of the layers initialized.
+func (l *layer) Clone() nnet.Layer {
+	if l.Weights == nil {
+		l.Weights = &data.Data{}
+		l.Biases = &data.Data{}
+	}
+
+	c := *l
+	if l.output != nil {
+		c.initBuffers()
+	}
+	return &c
+}
+
+// options returns layer with options of the convolution and without weights and buffers.
+func (l *layer) options() *layer {
+	return &layer{
+		FWidth:         l.FWidth,
+		FHeight:        l.FHeight,
+		FCount:         l.FCount,
+		Groups:         l.Groups,
+		FStrideX:       l.FStrideX,
+		FStrideY:       l.FStrideY,
+		FPaddingTop:    l.FPaddingTop,
+		FPaddingBottom: l.FPaddingBottom,
+		FPaddingLeft:   l.FPaddingLeft,
+		FPaddingRight:  l.FPaddingRight,
+		SamePadding:    l.SamePadding,
+		DilationX:      l.DilationX,
+		DilationY:      l.DilationY,
+	}
+}
+
+// UnmarshalJSON also reads stride and padding of layers saved before per-axis options.
+func (l *layer) UnmarshalJSON(b []byte) error {
+	type plain layer
+	if err := json.Unmarshal(b, (*plain)(l)); err != nil {
+		return err
+	}
+
+	legacy := struct {
+		FStride  *int
+		FPadding *int
+	}{}
+	if err := json.Unmarshal(b, &legacy); err != nil {
+		return err
+	}
+
+	if legacy.FStride != nil {
+		Stride(*legacy.FStride)(l)
+	}
+	if legacy.FPadding != nil {
+		Padding(*legacy.FPadding)(l)
+	}
+	return nil
+}
+
 func (l *layer) GetOutput() *data.Data {
 	return l.output
 }
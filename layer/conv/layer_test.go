@@ -1,7 +1,9 @@
 package conv
 
 import (
+	"encoding/json"
 	"github.com/drdreyworld/nnet/data"
+	"github.com/drdreyworld/nnet/gradcheck"
 	"github.com/stretchr/testify/assert"
 	"testing"
 )
@@ -150,3 +152,187 @@ func TestLayer(t *testing.T) {
 		assert.Equal(t, expected, g)
 	}
 }
+
+func TestLayer_Batch(t *testing.T) {
+	layer := New(FilterSize(2), FiltersCount(2), Padding(1))
+	layer.InitDataSizes(3, 3, 2)
+
+	res := gradcheck.CheckBatch(layer, gradcheck.Inputs(3, 3, 2, 2, 1))
+	assert.Equal(t, 0.0, res.Output)
+	assert.Equal(t, 0.0, res.Inputs)
+
+	// gradients of the batch are summed by the samples in other order
+	assert.InDelta(t, 0, res.Weights, 1e-12)
+	assert.InDelta(t, 0, res.Biases, 1e-12)
+}
+
+func TestLayer_Clone(t *testing.T) {
+	original := New(FiltersCount(2), Padding(1))
+	original.InitDataSizes(3, 3, 2)
+
+	clone := original.Clone().(*layer)
+	assert.Same(t, original.Weights, clone.Weights)
+	assert.True(t, &original.colIndexes[0] == &clone.colIndexes[0], "index tables depend only on sizes")
+	assert.False(t, &original.packedWeights[0] == &clone.packedWeights[0])
+	assert.False(t, &original.columns[0] == &clone.columns[0])
+
+	inputs := gradcheck.Inputs(3, 3, 2, 1, 1)
+	expected := original.Activate(inputs).Copy()
+
+	clone.Activate(gradcheck.Inputs(3, 3, 2, 2, 2))
+	assert.Equal(t, expected, original.GetOutput(), "clone changed output of the original")
+	assert.Equal(t, expected, clone.Activate(inputs))
+}
+
+func TestLayer_InitDataSizes(t *testing.T) {
+	type testCase struct {
+		options    []Option
+		iw, ih     int
+		ow, oh     int
+		top, left  int
+		bottom, rt int
+	}
+	testCases := map[string]testCase{
+		"NonSquare":   {options: []Option{FilterSizes(1, 7)}, iw: 10, ih: 10, ow: 10, oh: 4},
+		"Strides":     {options: []Option{FilterSize(3), Strides(1, 2)}, iw: 10, ih: 10, ow: 8, oh: 4},
+		"Paddings":    {options: []Option{FilterSize(3), Paddings(1, 0, 2, 3)}, iw: 10, ih: 10, ow: 13, oh: 9, top: 1, left: 2, rt: 3},
+		"Dilation":    {options: []Option{FilterSize(3), Dilations(2, 3)}, iw: 10, ih: 10, ow: 6, oh: 4},
+		"Same":        {options: []Option{FilterSize(3), SamePadding()}, iw: 7, ih: 6, ow: 7, oh: 6, top: 1, left: 1, bottom: 1, rt: 1},
+		"SameEven":    {options: []Option{FilterSizes(4, 2), SamePadding()}, iw: 7, ih: 6, ow: 7, oh: 6, left: 1, bottom: 1, rt: 2},
+		"SameStride":  {options: []Option{FilterSize(3), Stride(2), SamePadding()}, iw: 7, ih: 6, ow: 4, oh: 3, top: 0, left: 1, bottom: 1, rt: 1},
+		"SameDilated": {options: []Option{FilterSize(3), Dilation(2), SamePadding()}, iw: 7, ih: 6, ow: 7, oh: 6, top: 2, left: 2, bottom: 2, rt: 2},
+	}
+
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			layer := New(tc.options...)
+
+			ow, oh, _ := layer.InitDataSizes(tc.iw, tc.ih, 1)
+			assert.Equal(t, []int{tc.ow, tc.oh}, []int{ow, oh})
+			assert.Equal(t,
+				[]int{tc.top, tc.bottom, tc.left, tc.rt},
+				[]int{layer.FPaddingTop, layer.FPaddingBottom, layer.FPaddingLeft, layer.FPaddingRight},
+			)
+		})
+	}
+}
+
+func TestLayer_UnmarshalLegacyJSON(t *testing.T) {
+	layer := New()
+	assert.NoError(t, json.Unmarshal([]byte(`{"FWidth":2,"FHeight":2,"FCount":3,"FStride":2,"FPadding":1}`), layer))
+
+	assert.Equal(t, []int{2, 2}, []int{layer.FStrideX, layer.FStrideY})
+	assert.Equal(t, []int{1, 1, 1, 1}, []int{layer.FPaddingTop, layer.FPaddingBottom, layer.FPaddingLeft, layer.FPaddingRight})
+	assert.Equal(t, 3, layer.FCount)
+}
+
+func TestLayer_Gradients(t *testing.T) {
+	type testCase struct {
+		options    []Option
+		iw, ih, id int
+		n          int
+	}
+	testCases := map[string]testCase{
+		"Default": {options: []Option{}, iw: 4, ih: 4, id: 1, n: 1},
+		"Filters": {options: []Option{FilterSize(2), FiltersCount(3)}, iw: 3, ih: 3, id: 2, n: 1},
+		"Stride":  {options: []Option{FilterSize(2), FiltersCount(2), Stride(2)}, iw: 5, ih: 5, id: 2, n: 1},
+		"Padding": {options: []Option{FilterSize(3), FiltersCount(2), Padding(1)}, iw: 4, ih: 3, id: 2, n: 1},
+		"Batch":   {options: []Option{FilterSize(2), FiltersCount(2), Padding(1), Stride(2)}, iw: 4, ih: 4, id: 2, n: 3},
+	}
+
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			layer := New(tc.options...)
+			layer.InitDataSizes(tc.iw, tc.ih, tc.id)
+
+			// output is linear in every single input and parameter, so a big step has no truncation error
+			// and smaller rounding error
+			res := gradcheck.Check(layer, gradcheck.Inputs(tc.iw, tc.ih, tc.id, tc.n, 1), gradcheck.Epsilon(1e-2))
+			assert.Less(t, res.Max(), 1e-7, "%+v", res)
+		})
+	}
+}
+
+func TestLayer_Groups(t *testing.T) {
+	inputs := gradcheck.Inputs(4, 4, 4, 2, 1)
+
+	grouped := New(FilterSize(3), FiltersCount(6), Groups(2))
+	grouped.InitDataSizes(4, 4, 4)
+
+	// every group is equal to convolution of the half of input channels
+	halves := []*layer{New(FilterSize(3), FiltersCount(3)), New(FilterSize(3), FiltersCount(3))}
+	output := grouped.Activate(inputs)
+
+	for g, half := range halves {
+		half.InitDataSizes(4, 4, 2)
+
+		size := len(half.Weights.Data)
+		copy(half.Weights.Data, grouped.Weights.Data[g*size:(g+1)*size])
+		copy(half.Biases.Data, grouped.Biases.Data[g*3:(g+1)*3])
+
+		halfInputs := &data.Data{}
+		halfInputs.InitBatch(4, 4, 2, 2)
+		for s := 0; s < 2; s++ {
+			copy(halfInputs.Data[s*32:(s+1)*32], inputs.Data[s*64+g*32:s*64+(g+1)*32])
+		}
+
+		halfOutput := half.Activate(halfInputs)
+		for s := 0; s < 2; s++ {
+			assert.Equal(t, halfOutput.Data[s*12:(s+1)*12], output.Data[s*24+g*12:s*24+(g+1)*12])
+		}
+	}
+}
+
+func TestLayer_GroupsInvalid(t *testing.T) {
+	w, h, d := New(Groups(2), FiltersCount(3)).InitDataSizes(4, 4, 4)
+	assert.Equal(t, []int{0, 0, 0}, []int{w, h, d}, "filters count is not divisible by groups")
+
+	w, h, d = New(Groups(3), FiltersCount(3)).InitDataSizes(4, 4, 4)
+	assert.Equal(t, []int{0, 0, 0}, []int{w, h, d}, "input depth is not divisible by groups")
+}
+
+func TestLayer_Im2col(t *testing.T) {
+	type testCase struct {
+		options    []Option
+		iw, ih, id int
+		n          int
+	}
+	testCases := map[string]testCase{
+		"Default":  {options: []Option{}, iw: 5, ih: 5, id: 1, n: 1},
+		"Filters":  {options: []Option{FilterSizes(2, 3), FiltersCount(3)}, iw: 6, ih: 5, id: 3, n: 2},
+		"Strides":  {options: []Option{FiltersCount(2), Strides(2, 1), Paddings(1, 0, 2, 1)}, iw: 7, ih: 6, id: 2, n: 3},
+		"Same":     {options: []Option{FiltersCount(2), Stride(2), SamePadding()}, iw: 7, ih: 7, id: 2, n: 1},
+		"Dilation": {options: []Option{FiltersCount(2), Dilations(2, 1), Padding(1)}, iw: 8, ih: 6, id: 2, n: 2},
+		"Groups":   {options: []Option{FiltersCount(4), Groups(2), Padding(1)}, iw: 5, ih: 5, id: 4, n: 2},
+		"Large":    {options: []Option{FiltersCount(4), Padding(1)}, iw: 12, ih: 12, id: 3, n: 2},
+	}
+
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			im2col := New(tc.options...)
+			im2col.InitDataSizes(tc.iw, tc.ih, tc.id)
+
+			loops := New(append(tc.options, Loops())...)
+			loops.Weights, loops.Biases = im2col.Weights, im2col.Biases
+			ow, oh, od := loops.InitDataSizes(tc.iw, tc.ih, tc.id)
+
+			inputs := gradcheck.Inputs(tc.iw, tc.ih, tc.id, tc.n, 1)
+			deltas := gradcheck.Inputs(ow, oh, od, tc.n, 2)
+
+			for step := 0; step < 2; step++ {
+				assert.Equal(t, loops.Activate(inputs), im2col.Activate(inputs))
+				assert.Equal(t, loops.Backprop(deltas), im2col.Backprop(deltas))
+				assert.Equal(t, loops.gradWeights, im2col.gradWeights)
+				assert.Equal(t, loops.gradBiases, im2col.gradBiases)
+
+				// weights updated by optimizer are packed by the next Activate
+				for i := range im2col.Weights.Data {
+					im2col.Weights.Data[i] -= 0.1 * im2col.gradWeights.Data[i]
+				}
+			}
+		})
+	}
+}
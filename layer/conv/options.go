@@ -1,5 +1,7 @@
 package conv
 
+import "github.com/drdreyworld/nnet"
+
 type Option func(layer *layer)
 
 const (
@@ -8,20 +10,28 @@ const (
 	defaultFiltersCount = 1
 	defaultStride       = 1
 	defaultPadding      = 0
+	defaultDilation     = 1
+	defaultGroups       = 1
 )
 
 func defaults(layer *layer) {
 	layer.FWidth = defaultFilterWidth
 	layer.FHeight = defaultFilterHeight
 	layer.FCount = defaultFiltersCount
-	layer.FStride = defaultStride
-	layer.FPadding = defaultPadding
+	layer.Groups = defaultGroups
+	Stride(defaultStride)(layer)
+	Padding(defaultPadding)(layer)
+	Dilation(defaultDilation)(layer)
 }
 
 func FilterSize(size int) Option {
+	return FilterSizes(size, size)
+}
+
+func FilterSizes(width, height int) Option {
 	return func(layer *layer) {
-		layer.FWidth = size
-		layer.FHeight = size
+		layer.FWidth = width
+		layer.FHeight = height
 	}
 }
 
@@ -31,14 +41,126 @@ func FiltersCount(count int) Option {
 	}
 }
 
+// Groups connects every filter only to input channels of its group, input depth
+// and filters count must be divisible by groups, depthwise convolution has groups
+// equal to input depth.
+func Groups(groups int) Option {
+	return func(layer *layer) {
+		layer.Groups = groups
+	}
+}
+
 func Padding(padding int) Option {
+	return Paddings(padding, padding, padding, padding)
+}
+
+func Paddings(top, bottom, left, right int) Option {
 	return func(layer *layer) {
-		layer.FPadding = padding
+		layer.FPaddingTop, layer.FPaddingBottom = top, bottom
+		layer.FPaddingLeft, layer.FPaddingRight = left, right
+		layer.SamePadding = false
+	}
+}
+
+// SamePadding pads inputs so output size is input size divided by stride rounding up.
+func SamePadding() Option {
+	return func(layer *layer) {
+		layer.SamePadding = true
 	}
 }
 
 func Stride(stride int) Option {
+	return Strides(stride, stride)
+}
+
+func Strides(x, y int) Option {
+	return func(layer *layer) {
+		layer.FStrideX, layer.FStrideY = x, y
+	}
+}
+
+// Dilation sets step between filter inputs, one is the ordinary convolution.
+func Dilation(dilation int) Option {
+	return Dilations(dilation, dilation)
+}
+
+func Dilations(x, y int) Option {
+	return func(layer *layer) {
+		layer.DilationX, layer.DilationY = x, y
+	}
+}
+
+// Loops makes layer compute convolution by nested loops instead of im2col and matrix multiply.
+func Loops() Option {
 	return func(layer *layer) {
-		layer.FStride = stride
+		layer.Loops = true
+	}
+}
+
+type config struct {
+	FilterSize   *int
+	FilterSizes  *[2]int
+	FiltersCount *int
+	Groups       *int
+	Padding      *int
+	Paddings     *[4]int
+	SamePadding  *bool
+	Stride       *int
+	Strides      *[2]int
+	Dilation     *int
+	Dilations    *[2]int
+	Loops        *bool
+}
+
+func newFromOptions(options nnet.LayerOptions) (nnet.Layer, error) {
+	c := config{}
+	if options != nil {
+		if err := options.Decode(&c); err != nil {
+			return nil, err
+		}
+	}
+
+	layer := New()
+	c.apply(layer)
+
+	return layer, nil
+}
+
+func (c config) apply(layer *layer) {
+	if c.FilterSize != nil {
+		FilterSize(*c.FilterSize)(layer)
+	}
+	if c.FilterSizes != nil {
+		FilterSizes(c.FilterSizes[0], c.FilterSizes[1])(layer)
+	}
+	if c.FiltersCount != nil {
+		FiltersCount(*c.FiltersCount)(layer)
+	}
+	if c.Groups != nil {
+		Groups(*c.Groups)(layer)
+	}
+	if c.Padding != nil {
+		Padding(*c.Padding)(layer)
+	}
+	if c.Paddings != nil {
+		Paddings(c.Paddings[0], c.Paddings[1], c.Paddings[2], c.Paddings[3])(layer)
+	}
+	if c.SamePadding != nil && *c.SamePadding {
+		SamePadding()(layer)
+	}
+	if c.Stride != nil {
+		Stride(*c.Stride)(layer)
+	}
+	if c.Strides != nil {
+		Strides(c.Strides[0], c.Strides[1])(layer)
+	}
+	if c.Dilation != nil {
+		Dilation(*c.Dilation)(layer)
+	}
+	if c.Dilations != nil {
+		Dilations(c.Dilations[0], c.Dilations[1])(layer)
+	}
+	if c.Loops != nil {
+		layer.Loops = *c.Loops
 	}
 }
@@ -25,12 +25,41 @@ func TestPadding(t *testing.T) {
 	layer := &layer{}
 
 	Padding(3)(layer)
-	assert.Equal(t, layer.FPadding, 3)
+	assert.Equal(t, []int{3, 3, 3, 3}, []int{layer.FPaddingTop, layer.FPaddingBottom, layer.FPaddingLeft, layer.FPaddingRight})
+}
+
+func TestPaddings(t *testing.T) {
+	layer := &layer{SamePadding: true}
+
+	Paddings(1, 2, 3, 4)(layer)
+	assert.Equal(t, []int{1, 2, 3, 4}, []int{layer.FPaddingTop, layer.FPaddingBottom, layer.FPaddingLeft, layer.FPaddingRight})
+	assert.False(t, layer.SamePadding)
+}
+
+func TestFilterSizes(t *testing.T) {
+	layer := &layer{}
+
+	FilterSizes(1, 7)(layer)
+	assert.Equal(t, layer.FWidth, 1)
+	assert.Equal(t, layer.FHeight, 7)
+}
+
+func TestDilations(t *testing.T) {
+	layer := &layer{}
+
+	Dilation(2)(layer)
+	assert.Equal(t, []int{2, 2}, []int{layer.DilationX, layer.DilationY})
+
+	Dilations(3, 4)(layer)
+	assert.Equal(t, []int{3, 4}, []int{layer.DilationX, layer.DilationY})
 }
 
 func TestStride(t *testing.T) {
 	layer := &layer{}
 
 	Stride(7)(layer)
-	assert.Equal(t, layer.FStride, 7)
+	assert.Equal(t, []int{7, 7}, []int{layer.FStrideX, layer.FStrideY})
+
+	Strides(2, 3)(layer)
+	assert.Equal(t, []int{2, 3}, []int{layer.FStrideX, layer.FStrideY})
 }
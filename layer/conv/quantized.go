@@ -0,0 +1,157 @@
+package conv
+
+import (
+	"github.com/drdreyworld/nnet"
+	"github.com/drdreyworld/nnet/data"
+)
+
+func init() {
+	nnet.RegisterLayer("conv-int8", func(options nnet.LayerOptions) (nnet.Layer, error) {
+		return &quantized{Conv: New()}, nil
+	})
+}
+
+// Quantize returns inference only copy of the layer with int8 weights scaled per filter.
+// Inputs are quantized by inputScale, see data.Int8Scale.
+func (l *layer) Quantize(inputScale float64) nnet.Layer {
+	return &quantized{
+		Conv:       l.options(),
+		Weights:    l.Weights.Int8(l.FCount),
+		Biases:     l.Biases.Copy(),
+		InputScale: inputScale,
+	}
+}
+
+// quantized multiplies int8 input columns by int8 filters and accumulates products in int32,
+// so filter volume is limited by data.MaxInt8Products.
+type quantized struct {
+	// Conv holds options and sizes of the convolution, its weights are not used
+	Conv *layer
+
+	Weights    *data.Int8
+	Biases     *data.Data
+	InputScale float64
+
+	inputs        []int8
+	columns       []int8
+	packedWeights []int8
+	output        *data.Data
+
+	gradInputs *data.Data
+
+	batchSize int
+}
+
+func (l *quantized) InitDataSizes(iw, ih, id int) (int, int, int) {
+	c := l.Conv
+
+	w, h, d := c.initSizes(iw, ih, id)
+	if w < 1 || h < 1 || d < 1 {
+		return w, h, d
+	}
+
+	if l.Weights == nil || len(l.Weights.Data) != c.FCount*c.wCube {
+		return 0, 0, 0
+	}
+
+	if c.wCube > data.MaxInt8Products {
+		return 0, 0, 0
+	}
+
+	c.initColIndexes()
+
+	l.packedWeights = make([]int8, len(l.Weights.Data))
+	for f := 0; f < c.FCount; f++ {
+		for i, index := range c.weightIndexes {
+			l.packedWeights[f*c.wCube+i] = l.Weights.Data[f*c.wCube+index]
+		}
+	}
+
+	l.initBuffers()
+
+	return w, h, d
+}
+
+func (l *quantized) initBuffers() {
+	c := l.Conv
+
+	l.inputs = make([]int8, c.iCube)
+	l.columns = make([]int8, c.oSquare*c.wCube)
+
+	l.output = &data.Data{}
+	l.output.InitCube(c.oWidth, c.oHeight, c.oDepth)
+	l.gradInputs = &data.Data{}
+	l.gradInputs.InitCube(c.iWidth, c.iHeight, c.iDepth)
+	l.batchSize = 1
+}
+
+func (l *quantized) Activate(inputs *data.Data) *data.Data {
+	c := l.Conv
+
+	if n := inputs.GetBatchSize(); n != l.batchSize {
+		l.batchSize = n
+		l.output.InitBatch(c.oWidth, c.oHeight, c.oDepth, n)
+		l.gradInputs.InitBatch(c.iWidth, c.iHeight, c.iDepth, n)
+	}
+
+	k := c.wCube
+
+	for s := 0; s < l.batchSize; s++ {
+		for i, v := range inputs.Data[s*c.iCube : (s+1)*c.iCube] {
+			l.inputs[i] = data.QuantizeInt8(v, l.InputScale)
+		}
+
+		output := l.output.Data[s*c.oCube : (s+1)*c.oCube]
+
+		for g := 0; g < c.Groups; g++ {
+			groupInputs := l.inputs[g*c.FDepth*c.iSquare:]
+			for i, index := range c.colIndexes {
+				if index < 0 {
+					l.columns[i] = 0
+				} else {
+					l.columns[i] = groupInputs[index]
+				}
+			}
+
+			for filterIndex := g * c.groupFilters; filterIndex < (g+1)*c.groupFilters; filterIndex++ {
+				weights := l.packedWeights[filterIndex*k : (filterIndex+1)*k]
+				filterOutput := output[filterIndex*c.oSquare : (filterIndex+1)*c.oSquare]
+				scale := l.InputScale * l.Weights.Scales[filterIndex]
+
+				for p := range filterOutput {
+					column := l.columns[p*k : (p+1)*k]
+					column = column[:len(weights)]
+
+					var o int32
+					for i, w := range weights {
+						o += int32(w) * int32(column[i])
+					}
+					filterOutput[p] = float64(o)*scale + l.Biases.Data[filterIndex]
+				}
+			}
+		}
+	}
+
+	return l.output
+}
+
+// Backprop returns zero gradients of inputs, quantized layer is used only for inference.
+// It has no trainable parameters, so training does not change it.
+func (l *quantized) Backprop(deltas *data.Data) *data.Data {
+	return l.gradInputs
+}
+
+// Clone returns layer sharing weights and index tables, but with own buffers.
+func (l *quantized) Clone() nnet.Layer {
+	c := *l
+	conv := *l.Conv
+	c.Conv = &conv
+	if l.output != nil {
+		c.initBuffers()
+	}
+	return &c
+}
+
+func (l *quantized) GetOutput() *data.Data {
+	return l.output
+}
@@ -0,0 +1,146 @@
+package conv
+
+import (
+	"github.com/drdreyworld/nnet"
+	"github.com/drdreyworld/nnet/data"
+)
+
+func init() {
+	nnet.RegisterLayer("separable", newSeparableFromOptions)
+}
+
+// NewSeparable creates depthwise-separable convolution: depthwise convolution with multiplier
+// filters for every input channel followed by 1x1 convolution with filtersCount filters.
+// Options configure the depthwise convolution, its filters count and groups are set by InitDataSizes.
+func NewSeparable(filtersCount, multiplier int, options ...Option) *separable {
+	return &separable{
+		Multiplier: multiplier,
+		Depthwise:  New(options...),
+		Pointwise:  New(FilterSize(1), FiltersCount(filtersCount)),
+	}
+}
+
+// separable exposes weights and biases of both convolutions as single data,
+// weights of the convolutions are views of it.
+type separable struct {
+	Multiplier int
+
+	Depthwise *layer
+	Pointwise *layer
+
+	weights *data.Data
+	biases  *data.Data
+
+	gradWeights *data.Data
+	gradBiases  *data.Data
+}
+
+func (s *separable) InitDataSizes(w, h, d int) (int, int, int) {
+	if s.Multiplier < 1 {
+		s.Multiplier = 1
+	}
+
+	s.Depthwise.Groups = d
+	s.Depthwise.FCount = d * s.Multiplier
+
+	if w, h, d = s.Depthwise.InitDataSizes(w, h, d); w < 1 || h < 1 || d < 1 {
+		return w, h, d
+	}
+
+	if w, h, d = s.Pointwise.InitDataSizes(w, h, d); w < 1 || h < 1 || d < 1 {
+		return w, h, d
+	}
+
+	s.weights = join(s.Depthwise.Weights, s.Pointwise.Weights)
+	s.biases = join(s.Depthwise.Biases, s.Pointwise.Biases)
+	s.initGradients()
+
+	return w, h, d
+}
+
+func (s *separable) initGradients() {
+	s.gradWeights = join(s.Depthwise.gradWeights, s.Pointwise.gradWeights)
+	s.gradBiases = join(s.Depthwise.gradBiases, s.Pointwise.gradBiases)
+}
+
+// join returns vector with values of a and b and makes a and b views of it.
+func join(a, b *data.Data) *data.Data {
+	n := len(a.Data)
+
+	res := &data.Data{}
+	res.InitVector(n + len(b.Data))
+	copy(res.Data, a.Data)
+	copy(res.Data[n:], b.Data)
+
+	a.Data, b.Data = res.Data[:n:n], res.Data[n:]
+	return res
+}
+
+func (s *separable) Activate(inputs *data.Data) *data.Data {
+	return s.Pointwise.Activate(s.Depthwise.Activate(inputs))
+}
+
+func (s *separable) Backprop(deltas *data.Data) *data.Data {
+	return s.Depthwise.Backprop(s.Pointwise.Backprop(deltas))
+}
+
+// Clone returns layer with the same options and shared parameters, but with own buffers.
+func (s *separable) Clone() nnet.Layer {
+	c := *s
+	c.Depthwise = s.Depthwise.Clone().(*layer)
+	c.Pointwise = s.Pointwise.Clone().(*layer)
+	c.initGradients()
+	return &c
+}
+
+func (s *separable) GetOutput() *data.Data {
+	return s.Pointwise.GetOutput()
+}
+
+func (s *separable) GetWeights() *data.Data {
+	return s.weights
+}
+
+func (s *separable) GetBiases() *data.Data {
+	return s.biases
+}
+
+func (s *separable) GetWeightsWithGradient() (*data.Data, *data.Data) {
+	return s.weights, s.gradWeights
+}
+
+func (s *separable) GetBiasesWithGradient() (*data.Data, *data.Data) {
+	return s.biases, s.gradBiases
+}
+
+func (s *separable) GetInputGradients() *data.Data {
+	return s.Depthwise.GetInputGradients()
+}
+
+type separableConfig struct {
+	config
+	Multiplier *int
+}
+
+func newSeparableFromOptions(options nnet.LayerOptions) (nnet.Layer, error) {
+	c := separableConfig{}
+	if options != nil {
+		if err := options.Decode(&c); err != nil {
+			return nil, err
+		}
+	}
+
+	filtersCount, multiplier := defaultFiltersCount, 1
+	if c.FiltersCount != nil {
+		filtersCount = *c.FiltersCount
+	}
+	if c.Multiplier != nil {
+		multiplier = *c.Multiplier
+	}
+
+	c.FiltersCount = nil
+	layer := NewSeparable(filtersCount, multiplier)
+	c.apply(layer.Depthwise)
+
+	return layer, nil
+}
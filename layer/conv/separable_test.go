@@ -0,0 +1,72 @@
+package conv
+
+import (
+	"testing"
+
+	"github.com/drdreyworld/nnet/gradcheck"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSeparable_InitDataSizes(t *testing.T) {
+	layer := NewSeparable(5, 2, FilterSize(3), Padding(1))
+
+	w, h, d := layer.InitDataSizes(4, 4, 3)
+	assert.Equal(t, []int{4, 4, 5}, []int{w, h, d})
+
+	assert.Equal(t, 3, layer.Depthwise.Groups)
+	assert.Equal(t, 6, layer.Depthwise.FCount)
+
+	// 3x3 filter for every of 6 depthwise filters and 1x1x6 for every of 5 pointwise filters
+	assert.Len(t, layer.GetWeights().Data, 3*3*6+6*5)
+	assert.Len(t, layer.GetBiases().Data, 6+5)
+
+	layer.GetWeights().Data[0] = 42
+	assert.Equal(t, 42.0, layer.Depthwise.Weights.Data[0])
+
+	layer.GetWeights().Data[3*3*6] = 24
+	assert.Equal(t, 24.0, layer.Pointwise.Weights.Data[0])
+}
+
+func TestSeparable_Gradients(t *testing.T) {
+	type testCase struct {
+		layer      *separable
+		iw, ih, id int
+		n          int
+	}
+	testCases := map[string]testCase{
+		"Default":    {layer: NewSeparable(2, 1), iw: 4, ih: 4, id: 2, n: 1},
+		"Multiplier": {layer: NewSeparable(3, 2, FilterSize(2), Stride(2)), iw: 4, ih: 4, id: 2, n: 1},
+		"Batch":      {layer: NewSeparable(2, 1, FilterSize(3), SamePadding()), iw: 3, ih: 4, id: 3, n: 3},
+	}
+
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			tc.layer.InitDataSizes(tc.iw, tc.ih, tc.id)
+
+			// output is linear in every single input and parameter, so a big step has no truncation error
+			// and smaller rounding error
+			res := gradcheck.Check(tc.layer, gradcheck.Inputs(tc.iw, tc.ih, tc.id, tc.n, 1), gradcheck.Epsilon(1e-2))
+			assert.Less(t, res.Max(), 1e-7, "%+v", res)
+		})
+	}
+}
+
+func TestSeparable_Clone(t *testing.T) {
+	original := NewSeparable(2, 1)
+	original.InitDataSizes(4, 4, 2)
+
+	clone := original.Clone().(*separable)
+	assert.Same(t, original.GetWeights(), clone.GetWeights())
+	assert.Same(t, original.Depthwise.Weights, clone.Depthwise.Weights)
+
+	inputs := gradcheck.Inputs(4, 4, 2, 1, 1)
+	assert.Equal(t, original.Activate(inputs), clone.Activate(inputs))
+
+	clone.Backprop(clone.GetOutput())
+	_, gradWeights := clone.GetWeightsWithGradient()
+	_, originalGradWeights := original.GetWeightsWithGradient()
+
+	assert.NotEqual(t, gradWeights, originalGradWeights)
+	assert.Equal(t, clone.Depthwise.gradWeights.Data, gradWeights.Data[:len(clone.Depthwise.gradWeights.Data)])
+}
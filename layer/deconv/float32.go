@@ -0,0 +1,123 @@
+package deconv
+
+import (
+	"github.com/drdreyworld/nnet"
+	"github.com/drdreyworld/nnet/data"
+)
+
+func init() {
+	nnet.RegisterLayer("deconv-float32", func(options nnet.LayerOptions) (nnet.Layer, error) {
+		return &layer32{Deconv: New()}, nil
+	})
+}
+
+// Float32 returns inference only copy of the layer with weights and computations in float32.
+func (l *layer) Float32() nnet.Layer {
+	return &layer32{
+		Deconv:  l.options(),
+		Weights: l.Weights.Float32(),
+		Biases:  l.Biases.Float32(),
+	}
+}
+
+// layer32 rounds inputs to float32 and adds them multiplied by float32 filters to the output windows.
+type layer32 struct {
+	// Deconv holds options and sizes of the transposed convolution, its weights are not used
+	Deconv *layer
+
+	Weights *data.Data32
+	Biases  *data.Data32
+
+	inputs  []float32
+	outputs []float32
+	output  *data.Data
+
+	gradInputs *data.Data
+
+	batchSize int
+}
+
+func (l *layer32) InitDataSizes(iw, ih, id int) (int, int, int) {
+	c := l.Deconv
+
+	w, h, d := c.initSizes(iw, ih, id)
+	if w < 1 || h < 1 || d < 1 {
+		return w, h, d
+	}
+
+	if l.Weights == nil || len(l.Weights.Data) != c.FCount*c.wCube {
+		return 0, 0, 0
+	}
+
+	l.initBuffers()
+
+	return w, h, d
+}
+
+func (l *layer32) initBuffers() {
+	c := l.Deconv
+
+	l.inputs = make([]float32, c.iCube)
+	l.outputs = make([]float32, c.oCube)
+
+	l.output = &data.Data{}
+	l.output.InitCube(c.oWidth, c.oHeight, c.oDepth)
+	l.gradInputs = &data.Data{}
+	l.gradInputs.InitCube(c.iWidth, c.iHeight, c.iDepth)
+	l.batchSize = 1
+}
+
+func (l *layer32) Activate(inputs *data.Data) *data.Data {
+	c := l.Deconv
+
+	if n := inputs.GetBatchSize(); n != l.batchSize {
+		l.batchSize = n
+		l.output.InitBatch(c.oWidth, c.oHeight, c.oDepth, n)
+		l.gradInputs.InitBatch(c.iWidth, c.iHeight, c.iDepth, n)
+	}
+
+	for s := 0; s < l.batchSize; s++ {
+		for i, v := range inputs.Data[s*c.iCube : (s+1)*c.iCube] {
+			l.inputs[i] = float32(v)
+		}
+
+		for filterIndex := 0; filterIndex < c.FCount; filterIndex++ {
+			filterOutputs := l.outputs[filterIndex*c.oSquare : (filterIndex+1)*c.oSquare]
+			for i := range filterOutputs {
+				filterOutputs[i] = l.Biases.Data[filterIndex]
+			}
+		}
+
+		c.each(func(inXYZ, outXYZ, wtXYZ int) {
+			l.outputs[outXYZ] += l.inputs[inXYZ] * l.Weights.Data[wtXYZ]
+		})
+
+		output := l.output.Data[s*c.oCube : (s+1)*c.oCube]
+		for i, v := range l.outputs {
+			output[i] = float64(v)
+		}
+	}
+
+	return l.output
+}
+
+// Backprop returns zero gradients of inputs, float32 layer is used only for inference.
+// It has no trainable parameters, so training does not change it.
+func (l *layer32) Backprop(deltas *data.Data) *data.Data {
+	return l.gradInputs
+}
+
+// Clone returns layer sharing weights, but with own buffers.
+func (l *layer32) Clone() nnet.Layer {
+	c := *l
+	deconv := *l.Deconv
+	c.Deconv = &deconv
+	if l.output != nil {
+		c.initBuffers()
+	}
+	return &c
+}
+
+func (l *layer32) GetOutput() *data.Data {
+	return l.output
+}
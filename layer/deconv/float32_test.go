@@ -0,0 +1,47 @@
+package deconv
+
+import (
+	"testing"
+
+	"github.com/drdreyworld/nnet/gradcheck"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLayer_Float32(t *testing.T) {
+	type testCase struct {
+		options    []Option
+		iw, ih, id int
+		n          int
+	}
+	testCases := map[string]testCase{
+		"Default": {options: []Option{FiltersCount(3)}, iw: 4, ih: 4, id: 2, n: 1},
+		"Strides": {options: []Option{FiltersCount(2), Strides(2, 1), Padding(1), OutputPadding(1)}, iw: 4, ih: 3, id: 2, n: 3},
+	}
+
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			layer := New(tc.options...)
+			ow, oh, od := layer.InitDataSizes(tc.iw, tc.ih, tc.id)
+
+			inputs := gradcheck.Inputs(tc.iw, tc.ih, tc.id, tc.n, 1)
+			expected := layer.Activate(inputs).Copy()
+
+			single := layer.Float32()
+
+			w, h, d := single.InitDataSizes(tc.iw, tc.ih, tc.id)
+			assert.Equal(t, []int{ow, oh, od}, []int{w, h, d})
+
+			output := single.Activate(inputs)
+			assert.Equal(t, expected.Dims, output.Dims)
+			assert.InDeltaSlice(t, expected.Data, output.Data, 1e-5)
+
+			gradients := single.Backprop(output)
+			assert.Equal(t, inputs.Dims, gradients.Dims)
+			assert.Equal(t, make([]float64, len(inputs.Data)), gradients.Data, "inference only layer has zero gradients")
+
+			w, h, d = single.InitDataSizes(tc.iw, tc.ih, tc.id+1)
+			assert.Equal(t, []int{0, 0, 0}, []int{w, h, d}, "weights do not match inputs")
+		})
+	}
+}
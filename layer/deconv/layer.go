@@ -0,0 +1,288 @@
+package deconv
+
+import (
+	"math"
+
+	"github.com/drdreyworld/nnet"
+	"github.com/drdreyworld/nnet/data"
+)
+
+func init() {
+	nnet.RegisterLayer("deconv", newFromOptions)
+}
+
+func New(options ...Option) *layer {
+	layer := &layer{}
+	defaults(layer)
+
+	for _, opt := range options {
+		opt(layer)
+	}
+
+	return layer
+}
+
+// layer is transposed convolution: every input value is multiplied by the filter and added
+// to the output window at the input position multiplied by stride, so output size is
+// (input - 1) * stride + filter - padding + output padding. Padding crops the output.
+type layer struct {
+	iWidth, iHeight, iDepth int
+	FWidth, FHeight, FDepth int
+	oWidth, oHeight, oDepth int
+
+	FCount int
+
+	FStrideX, FStrideY int
+
+	FPaddingTop, FPaddingBottom int
+	FPaddingLeft, FPaddingRight int
+
+	// OutputPaddingX and OutputPaddingY are added to the right and bottom of the output
+	// to select one of the sizes which strided convolution maps to the same input size
+	OutputPaddingX, OutputPaddingY int
+
+	Weights *data.Data
+	Biases  *data.Data
+
+	inputs *data.Data
+	output *data.Data
+
+	gradWeights *data.Data
+	gradBiases  *data.Data
+	gradInputs  *data.Data
+
+	iSquare int
+	oSquare int
+	wSquare int
+	wCube   int
+
+	iCube int
+	oCube int
+
+	batchSize int
+}
+
+func (l *layer) InitDataSizes(iw, ih, id int) (int, int, int) {
+	w, h, d := l.initSizes(iw, ih, id)
+	if w < 1 || h < 1 || d < 1 {
+		return w, h, d
+	}
+
+	if l.Weights == nil {
+		l.Weights = &data.Data{}
+		l.Biases = &data.Data{}
+	}
+
+	if len(l.Weights.Data) == 0 {
+		maxWeight := math.Sqrt(1.0 / float64(l.FWidth*l.FHeight*l.FDepth))
+
+		l.Weights.InitCubeRandom(l.FWidth, l.FHeight, l.FCount*l.FDepth, -maxWeight, maxWeight)
+		l.Biases.InitVector(l.FCount)
+	}
+
+	l.initBuffers()
+
+	return w, h, d
+}
+
+// initSizes calculates output and filter sizes for the input sizes.
+func (l *layer) initSizes(iw, ih, id int) (int, int, int) {
+	if l.FStrideX < 1 {
+		l.FStrideX = 1
+	}
+	if l.FStrideY < 1 {
+		l.FStrideY = 1
+	}
+
+	l.iWidth, l.iHeight, l.iDepth = iw, ih, id
+
+	l.oWidth = (iw-1)*l.FStrideX + l.FWidth - l.FPaddingLeft - l.FPaddingRight + l.OutputPaddingX
+	l.oHeight = (ih-1)*l.FStrideY + l.FHeight - l.FPaddingTop - l.FPaddingBottom + l.OutputPaddingY
+
+	l.oDepth = l.FCount
+	l.FDepth = id
+
+	if l.oWidth < 1 || l.oHeight < 1 || l.oDepth < 1 {
+		return l.oWidth, l.oHeight, l.oDepth
+	}
+
+	l.iSquare = l.iWidth * l.iHeight
+	l.oSquare = l.oWidth * l.oHeight
+	l.wSquare = l.FWidth * l.FHeight
+	l.wCube = l.FDepth * l.wSquare
+
+	l.iCube = l.iDepth * l.iSquare
+	l.oCube = l.oDepth * l.oSquare
+
+	return l.oWidth, l.oHeight, l.oDepth
+}
+
+// initBuffers allocates output and gradients for the initialized sizes.
+func (l *layer) initBuffers() {
+	l.batchSize = 1
+
+	l.output = &data.Data{}
+	l.output.InitCube(l.oWidth, l.oHeight, l.oDepth)
+
+	l.gradBiases = &data.Data{}
+	l.gradBiases.InitVector(l.FCount)
+
+	l.gradWeights = &data.Data{}
+	l.gradWeights.InitCube(l.FWidth, l.FHeight, l.FCount*l.FDepth)
+
+	l.gradInputs = &data.Data{}
+	l.gradInputs.InitCube(l.iWidth, l.iHeight, l.iDepth)
+}
+
+func (l *layer) initBatch(n int) {
+	if l.batchSize == n {
+		return
+	}
+
+	l.batchSize = n
+	l.output.InitBatch(l.oWidth, l.oHeight, l.oDepth, n)
+	l.gradInputs.InitBatch(l.iWidth, l.iHeight, l.iDepth, n)
+}
+
+func (l *layer) Activate(inputs *data.Data) *data.Data {
+	l.inputs = inputs
+	l.initBatch(inputs.GetBatchSize())
+
+	for s := 0; s < l.batchSize; s++ {
+		l.activateSample(
+			l.inputs.Data[s*l.iCube:(s+1)*l.iCube],
+			l.output.Data[s*l.oCube:(s+1)*l.oCube],
+		)
+	}
+	return l.output
+}
+
+func (l *layer) activateSample(inputs, output []float64) {
+	for filterIndex := 0; filterIndex < l.FCount; filterIndex++ {
+		filterOutput := output[filterIndex*l.oSquare : (filterIndex+1)*l.oSquare]
+		for i := range filterOutput {
+			filterOutput[i] = l.Biases.Data[filterIndex]
+		}
+	}
+
+	l.each(func(inXYZ, outXYZ, wtXYZ int) {
+		output[outXYZ] += inputs[inXYZ] * l.Weights.Data[wtXYZ]
+	})
+}
+
+// Backprop sums weights and biases gradients over the batch samples.
+func (l *layer) Backprop(deltas *data.Data) *data.Data {
+	l.gradInputs.Reset()
+	l.gradWeights.Reset()
+	l.gradBiases.Reset()
+
+	for s := 0; s < l.batchSize; s++ {
+		l.backpropSample(
+			l.inputs.Data[s*l.iCube:(s+1)*l.iCube],
+			deltas.Data[s*l.oCube:(s+1)*l.oCube],
+			l.gradInputs.Data[s*l.iCube:(s+1)*l.iCube],
+		)
+	}
+
+	return l.gradInputs
+}
+
+func (l *layer) backpropSample(inputs, deltas, gradInputs []float64) {
+	for filterIndex := 0; filterIndex < l.FCount; filterIndex++ {
+		for _, delta := range deltas[filterIndex*l.oSquare : (filterIndex+1)*l.oSquare] {
+			l.gradBiases.Data[filterIndex] += delta
+		}
+	}
+
+	l.each(func(inXYZ, outXYZ, wtXYZ int) {
+		gradInputs[inXYZ] += l.Weights.Data[wtXYZ] * deltas[outXYZ]
+		l.gradWeights.Data[wtXYZ] += inputs[inXYZ] * deltas[outXYZ]
+	})
+}
+
+// each calls f for every input, output and weight indexes connected inside the output bounds.
+func (l *layer) each(f func(inXYZ, outXYZ, wtXYZ int)) {
+	for filterIndex := 0; filterIndex < l.FCount; filterIndex++ {
+		filterOutputOffset := filterIndex * l.oSquare
+		filterWeightsOffset := filterIndex * l.wCube
+
+		for iz := 0; iz < l.iDepth; iz++ {
+			for iy, initOutputY := 0, -l.FPaddingTop; iy < l.iHeight; iy, initOutputY = iy+1, initOutputY+l.FStrideY {
+				for ix, initOutputX := 0, -l.FPaddingLeft; ix < l.iWidth; ix, initOutputX = ix+1, initOutputX+l.FStrideX {
+					inXYZ := iz*l.iSquare + iy*l.iWidth + ix
+
+					for fy, oy := 0, initOutputY; fy < l.FHeight; fy, oy = fy+1, oy+1 {
+						if oy < 0 || oy >= l.oHeight {
+							continue
+						}
+
+						for fx, ox := 0, initOutputX; fx < l.FWidth; fx, ox = fx+1, ox+1 {
+							if ox < 0 || ox >= l.oWidth {
+								continue
+							}
+
+							f(inXYZ, filterOutputOffset+oy*l.oWidth+ox, filterWeightsOffset+iz*l.wSquare+fy*l.FWidth+fx)
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+// Clone returns layer with the same options and shared parameters, but with own buffers.
+// Parameters of not initialized layer are created empty, so they are initialized once
+// by the first of the layers initialized.
+func (l *layer) Clone() nnet.Layer {
+	if l.Weights == nil {
+		l.Weights = &data.Data{}
+		l.Biases = &data.Data{}
+	}
+
+	c := *l
+	if l.output != nil {
+		c.initBuffers()
+	}
+	return &c
+}
+
+// options returns layer with the options of the layer only.
+func (l *layer) options() *layer {
+	return &layer{
+		FWidth:         l.FWidth,
+		FHeight:        l.FHeight,
+		FCount:         l.FCount,
+		FStrideX:       l.FStrideX,
+		FStrideY:       l.FStrideY,
+		FPaddingTop:    l.FPaddingTop,
+		FPaddingBottom: l.FPaddingBottom,
+		FPaddingLeft:   l.FPaddingLeft,
+		FPaddingRight:  l.FPaddingRight,
+		OutputPaddingX: l.OutputPaddingX,
+		OutputPaddingY: l.OutputPaddingY,
+	}
+}
+
+func (l *layer) GetOutput() *data.Data {
+	return l.output
+}
+
+func (l *layer) GetWeights() *data.Data {
+	return l.Weights
+}
+
+func (l *layer) GetBiases() *data.Data {
+	return l.Biases
+}
+
+func (l *layer) GetWeightsWithGradient() (*data.Data, *data.Data) {
+	return l.Weights, l.gradWeights
+}
+
+func (l *layer) GetBiasesWithGradient() (*data.Data, *data.Data) {
+	return l.Biases, l.gradBiases
+}
+
+func (l *layer) GetInputGradients() *data.Data {
+	return l.gradInputs
+}
@@ -0,0 +1,115 @@
+package deconv
+
+import (
+	"testing"
+
+	"github.com/drdreyworld/nnet/data"
+	"github.com/drdreyworld/nnet/gradcheck"
+	"github.com/drdreyworld/nnet/layer/conv"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLayer_InitDataSizes(t *testing.T) {
+	type testCase struct {
+		options []Option
+		ow, oh  int
+	}
+	testCases := map[string]testCase{
+		"Default":       {options: []Option{}, ow: 6, oh: 5},
+		"Stride":        {options: []Option{FilterSize(2), Stride(2)}, ow: 8, oh: 6},
+		"Padding":       {options: []Option{FilterSize(3), Stride(2), Padding(1)}, ow: 7, oh: 5},
+		"OutputPadding": {options: []Option{FilterSize(3), Stride(2), Padding(1), OutputPadding(1)}, ow: 8, oh: 6},
+		"NonSquare":     {options: []Option{FilterSizes(1, 3), Strides(1, 3), Paddings(0, 1, 0, 0)}, ow: 4, oh: 8},
+	}
+
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			ow, oh, od := New(tc.options...).InitDataSizes(4, 3, 2)
+			assert.Equal(t, []int{tc.ow, tc.oh, 1}, []int{ow, oh, od})
+		})
+	}
+}
+
+func TestLayer_Activate(t *testing.T) {
+	layer := New(FilterSize(2), Stride(2))
+	layer.InitDataSizes(2, 1, 1)
+
+	layer.Weights.Data = []float64{
+		1, 2,
+		3, 4,
+	}
+	layer.Biases.Data = []float64{0.5}
+
+	output := layer.Activate(data.NewVector(1, 10))
+
+	assert.Equal(t, []int{4, 2, 1}, output.Dims)
+	assert.Equal(t, []float64{
+		1.5, 2.5, 10.5, 20.5,
+		3.5, 4.5, 30.5, 40.5,
+	}, output.Data)
+}
+
+// Transposed convolution without biases is adjoint of the convolution with the same filters:
+// <conv(x), y> = <x, deconv(y)>.
+func TestLayer_TransposeOfConv(t *testing.T) {
+	c := conv.New(conv.FilterSize(3), conv.FiltersCount(2), conv.Stride(2), conv.Padding(1))
+	cw, ch, cd := c.InitDataSizes(5, 5, 3)
+
+	d := New(FilterSize(3), FiltersCount(3), Stride(2), Padding(1))
+	dw, dh, dd := d.InitDataSizes(cw, ch, cd)
+	assert.Equal(t, []int{5, 5, 3}, []int{dw, dh, dd})
+
+	convWeights, _ := c.GetWeightsWithGradient()
+	convBiases, _ := c.GetBiasesWithGradient()
+	convBiases.Reset()
+
+	// conv weights are [filter][input channel][fy][fx], deconv ones are [output channel][input channel][fy][fx]
+	for f := 0; f < 2; f++ {
+		for z := 0; z < 3; z++ {
+			copy(d.Weights.Data[(z*2+f)*9:(z*2+f+1)*9], convWeights.Data[(f*3+z)*9:(f*3+z+1)*9])
+		}
+	}
+
+	x := gradcheck.Inputs(5, 5, 3, 1, 1)
+	y := gradcheck.Inputs(cw, ch, cd, 1, 2)
+
+	assert.InDelta(t, dot(c.Activate(x).Data, y.Data), dot(x.Data, d.Activate(y).Data), 1e-12)
+}
+
+func dot(a, b []float64) float64 {
+	res := 0.0
+	for i := range a {
+		res += a[i] * b[i]
+	}
+	return res
+}
+
+func TestLayer_Gradients(t *testing.T) {
+	type testCase struct {
+		options    []Option
+		iw, ih, id int
+		n          int
+	}
+	testCases := map[string]testCase{
+		"Default":   {options: []Option{FiltersCount(2)}, iw: 3, ih: 3, id: 2, n: 1},
+		"Stride":    {options: []Option{FilterSize(2), Stride(2), FiltersCount(2)}, iw: 3, ih: 2, id: 2, n: 1},
+		"Padding":   {options: []Option{FilterSize(3), Stride(2), Padding(1), OutputPadding(1)}, iw: 3, ih: 3, id: 1, n: 1},
+		"NonSquare": {options: []Option{FilterSizes(3, 1), Strides(2, 1), Paddings(0, 0, 1, 0)}, iw: 3, ih: 3, id: 2, n: 1},
+		"Batch":     {options: []Option{FilterSize(3), Stride(2), FiltersCount(2)}, iw: 2, ih: 2, id: 2, n: 3},
+	}
+
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			layer := New(tc.options...)
+			layer.InitDataSizes(tc.iw, tc.ih, tc.id)
+			layer.Biases.FillRandom(-1, 1)
+
+			// output is linear in every single input and parameter, so a big step has no truncation error
+			// and smaller rounding error
+			res := gradcheck.Check(layer, gradcheck.Inputs(tc.iw, tc.ih, tc.id, tc.n, 1), gradcheck.Epsilon(1e-2))
+			assert.Less(t, res.Max(), 1e-7, "%+v", res)
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package deconv
+
+import "github.com/drdreyworld/nnet"
+
+type Option func(layer *layer)
+
+const (
+	defaultFilterSize   = 3
+	defaultFiltersCount = 1
+	defaultStride       = 1
+	defaultPadding      = 0
+)
+
+func defaults(layer *layer) {
+	FilterSize(defaultFilterSize)(layer)
+	FiltersCount(defaultFiltersCount)(layer)
+	Stride(defaultStride)(layer)
+	Padding(defaultPadding)(layer)
+}
+
+func FilterSize(size int) Option {
+	return FilterSizes(size, size)
+}
+
+func FilterSizes(width, height int) Option {
+	return func(layer *layer) {
+		layer.FWidth = width
+		layer.FHeight = height
+	}
+}
+
+func FiltersCount(count int) Option {
+	return func(layer *layer) {
+		layer.FCount = count
+	}
+}
+
+func Padding(padding int) Option {
+	return Paddings(padding, padding, padding, padding)
+}
+
+func Paddings(top, bottom, left, right int) Option {
+	return func(layer *layer) {
+		layer.FPaddingTop, layer.FPaddingBottom = top, bottom
+		layer.FPaddingLeft, layer.FPaddingRight = left, right
+	}
+}
+
+func Stride(stride int) Option {
+	return Strides(stride, stride)
+}
+
+func Strides(x, y int) Option {
+	return func(layer *layer) {
+		layer.FStrideX, layer.FStrideY = x, y
+	}
+}
+
+func OutputPadding(padding int) Option {
+	return OutputPaddings(padding, padding)
+}
+
+func OutputPaddings(x, y int) Option {
+	return func(layer *layer) {
+		layer.OutputPaddingX, layer.OutputPaddingY = x, y
+	}
+}
+
+type config struct {
+	FilterSize     *int
+	FilterSizes    *[2]int
+	FiltersCount   *int
+	Padding        *int
+	Paddings       *[4]int
+	Stride         *int
+	Strides        *[2]int
+	OutputPadding  *int
+	OutputPaddings *[2]int
+}
+
+func newFromOptions(options nnet.LayerOptions) (nnet.Layer, error) {
+	c := config{}
+	if options != nil {
+		if err := options.Decode(&c); err != nil {
+			return nil, err
+		}
+	}
+
+	layer := New()
+	if c.FilterSize != nil {
+		FilterSize(*c.FilterSize)(layer)
+	}
+	if c.FilterSizes != nil {
+		FilterSizes(c.FilterSizes[0], c.FilterSizes[1])(layer)
+	}
+	if c.FiltersCount != nil {
+		FiltersCount(*c.FiltersCount)(layer)
+	}
+	if c.Padding != nil {
+		Padding(*c.Padding)(layer)
+	}
+	if c.Paddings != nil {
+		Paddings(c.Paddings[0], c.Paddings[1], c.Paddings[2], c.Paddings[3])(layer)
+	}
+	if c.Stride != nil {
+		Stride(*c.Stride)(layer)
+	}
+	if c.Strides != nil {
+		Strides(c.Strides[0], c.Strides[1])(layer)
+	}
+	if c.OutputPadding != nil {
+		OutputPadding(*c.OutputPadding)(layer)
+	}
+	if c.OutputPaddings != nil {
+		OutputPaddings(c.OutputPaddings[0], c.OutputPaddings[1])(layer)
+	}
+
+	return layer, nil
+}
@@ -0,0 +1,145 @@
+package dropout
+
+import (
+	"math/rand"
+	"sync/atomic"
+
+	"github.com/drdreyworld/nnet"
+	"github.com/drdreyworld/nnet/data"
+)
+
+func init() {
+	nnet.RegisterLayer("dropout", newFromOptions)
+}
+
+func New(options ...Option) *layer {
+	layer := &layer{training: true, clones: new(int64)}
+	defaults(layer)
+
+	for _, opt := range options {
+		opt(layer)
+	}
+
+	return layer
+}
+
+// layer zeroes inputs with the rate probability in training mode and scales the kept
+// ones by 1 / (1 - rate), so in eval mode it passes inputs as is. With Spatial option
+// whole channels are dropped.
+type layer struct {
+	IWidth, IHeight, IDepth int
+
+	Rate    float64
+	Spatial bool
+	Seed    int64
+
+	rnd    *rand.Rand
+	clones *int64
+
+	output     *data.Data
+	mask       *data.Data
+	gradInputs *data.Data
+
+	batchSize int
+	training  bool
+}
+
+func (l *layer) InitDataSizes(w, h, d int) (int, int, int) {
+	l.IWidth, l.IHeight, l.IDepth = w, h, d
+
+	if l.rnd == nil {
+		l.rnd = rand.New(rand.NewSource(l.Seed))
+	}
+
+	l.batchSize = 1
+	l.output = &data.Data{}
+	l.output.InitCube(w, h, d)
+	l.mask = &data.Data{}
+	l.mask.InitCube(w, h, d)
+	l.gradInputs = &data.Data{}
+	l.gradInputs.InitCube(w, h, d)
+
+	return w, h, d
+}
+
+func (l *layer) initBatch(n int) {
+	if l.batchSize == n {
+		return
+	}
+
+	l.batchSize = n
+	l.output.InitBatch(l.IWidth, l.IHeight, l.IDepth, n)
+	l.mask.InitBatch(l.IWidth, l.IHeight, l.IDepth, n)
+	l.gradInputs.InitBatch(l.IWidth, l.IHeight, l.IDepth, n)
+}
+
+func (l *layer) Activate(inputs *data.Data) *data.Data {
+	l.initBatch(inputs.GetBatchSize())
+
+	if !l.training || l.Rate <= 0 {
+		l.mask.Fill(1)
+	} else {
+		l.fillMask()
+	}
+
+	for i, v := range inputs.Data {
+		l.output.Data[i] = v * l.mask.Data[i]
+	}
+
+	return l.output
+}
+
+func (l *layer) fillMask() {
+	keep := 0.0
+	if l.Rate < 1 {
+		keep = 1 / (1 - l.Rate)
+	}
+
+	size := 1
+	if l.Spatial {
+		size = l.IWidth * l.IHeight
+	}
+
+	for i := 0; i < len(l.mask.Data); i += size {
+		v := keep
+		if l.rnd.Float64() < l.Rate {
+			v = 0
+		}
+
+		for j := i; j < i+size; j++ {
+			l.mask.Data[j] = v
+		}
+	}
+}
+
+func (l *layer) Backprop(deltas *data.Data) *data.Data {
+	for i, d := range deltas.Data {
+		l.gradInputs.Data[i] = d * l.mask.Data[i]
+	}
+	return l.gradInputs
+}
+
+func (l *layer) Train() {
+	l.training = true
+}
+
+func (l *layer) Eval() {
+	l.training = false
+}
+
+// Clone returns layer with the same options, but with own buffers and random generator,
+// seeded by the layer seed and the clone number.
+func (l *layer) Clone() nnet.Layer {
+	c := *l
+	c.rnd = rand.New(rand.NewSource(l.Seed + atomic.AddInt64(l.clones, 1)))
+	c.InitDataSizes(l.IWidth, l.IHeight, l.IDepth)
+	return &c
+}
+
+func (l *layer) GetOutput() *data.Data {
+	return l.output
+}
+
+func (l *layer) GetInputGradients() *data.Data {
+	return l.gradInputs
+}
@@ -0,0 +1,98 @@
+package dropout
+
+import (
+	"testing"
+
+	"github.com/drdreyworld/nnet/data"
+	"github.com/drdreyworld/nnet/gradcheck"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLayer_Activate(t *testing.T) {
+	layer := New(Rate(0.25), Seed(7))
+	layer.InitDataSizes(10, 10, 10)
+
+	inputs := &data.Data{}
+	inputs.InitBatch(10, 10, 10, 2)
+	inputs.Fill(3)
+
+	output := layer.Activate(inputs)
+
+	dropped := 0
+	for _, v := range output.Data {
+		if v == 0 {
+			dropped++
+		} else {
+			assert.Equal(t, 4.0, v)
+		}
+	}
+	assert.InDelta(t, 0.25, float64(dropped)/float64(len(output.Data)), 0.03)
+
+	deltas := inputs.Copy()
+	deltas.Fill(1.5)
+	gradInputs := layer.Backprop(deltas)
+
+	for i, v := range output.Data {
+		assert.Equal(t, v/2, gradInputs.Data[i])
+	}
+}
+
+func TestLayer_Spatial(t *testing.T) {
+	layer := New(Spatial(), Seed(3))
+	layer.InitDataSizes(3, 2, 20)
+
+	inputs := &data.Data{}
+	inputs.InitCube(3, 2, 20)
+	inputs.Fill(1)
+
+	output := layer.Activate(inputs)
+
+	dropped := 0
+	for c := 0; c < 20; c++ {
+		channel := output.Data[c*6 : (c+1)*6]
+		for _, v := range channel {
+			assert.Equal(t, channel[0], v, "channel %d is dropped partially", c)
+		}
+		if channel[0] == 0 {
+			dropped++
+		}
+	}
+	assert.True(t, dropped > 0 && dropped < 20)
+}
+
+func TestLayer_Seed(t *testing.T) {
+	inputs := gradcheck.Inputs(4, 4, 2, 1, 1)
+
+	a, b, c := New(Seed(5)), New(Seed(5)), New(Seed(6))
+	for _, l := range []*layer{a, b, c} {
+		l.InitDataSizes(4, 4, 2)
+	}
+
+	assert.Equal(t, a.Activate(inputs), b.Activate(inputs))
+	assert.NotEqual(t, a.Activate(inputs), c.Activate(inputs))
+}
+
+func TestLayer_Eval(t *testing.T) {
+	layer := New(Rate(0.9))
+	layer.InitDataSizes(3, 3, 1)
+	layer.Eval()
+
+	inputs := gradcheck.Inputs(3, 3, 1, 2, 1)
+	assert.Equal(t, inputs.Data, layer.Activate(inputs).Data)
+
+	res := gradcheck.Check(layer, inputs)
+	assert.Less(t, res.Max(), 1e-7, "%+v", res)
+
+	layer.Train()
+	assert.NotEqual(t, inputs.Data, layer.Activate(inputs).Data)
+}
+
+func TestLayer_Clone(t *testing.T) {
+	original := New()
+	original.InitDataSizes(4, 4, 1)
+
+	inputs := gradcheck.Inputs(4, 4, 1, 1, 1)
+
+	a, b := original.Clone(), original.Clone()
+	assert.NotEqual(t, a.Activate(inputs), b.Activate(inputs), "clones share random generator seed")
+}
@@ -0,0 +1,64 @@
+package dropout
+
+import "github.com/drdreyworld/nnet"
+
+type Option func(layer *layer)
+
+const (
+	defaultRate = 0.5
+	defaultSeed = 1
+)
+
+func defaults(layer *layer) {
+	layer.Rate = defaultRate
+	layer.Seed = defaultSeed
+}
+
+// Rate sets probability to drop an input.
+func Rate(rate float64) Option {
+	return func(layer *layer) {
+		layer.Rate = rate
+	}
+}
+
+// Spatial makes layer drop whole channels of conv feature maps.
+func Spatial() Option {
+	return func(layer *layer) {
+		layer.Spatial = true
+	}
+}
+
+// Seed makes dropped inputs reproducible.
+func Seed(seed int64) Option {
+	return func(layer *layer) {
+		layer.Seed = seed
+	}
+}
+
+type config struct {
+	Rate    *float64
+	Spatial *bool
+	Seed    *int64
+}
+
+func newFromOptions(options nnet.LayerOptions) (nnet.Layer, error) {
+	c := config{}
+	if options != nil {
+		if err := options.Decode(&c); err != nil {
+			return nil, err
+		}
+	}
+
+	layer := New()
+	if c.Rate != nil {
+		Rate(*c.Rate)(layer)
+	}
+	if c.Spatial != nil {
+		layer.Spatial = *c.Spatial
+	}
+	if c.Seed != nil {
+		Seed(*c.Seed)(layer)
+	}
+
+	return layer, nil
+}
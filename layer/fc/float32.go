@@ -0,0 +1,117 @@
+package fc
+
+import (
+	"github.com/drdreyworld/nnet"
+	"github.com/drdreyworld/nnet/data"
+	"github.com/drdreyworld/nnet/linalg"
+)
+
+func init() {
+	nnet.RegisterLayer("fc-float32", func(options nnet.LayerOptions) (nnet.Layer, error) {
+		return &layer32{}, nil
+	})
+}
+
+// Float32 returns inference only copy of the layer with weights and computations in float32.
+func (l *layer) Float32() nnet.Layer {
+	return &layer32{
+		OWidth:  l.OWidth,
+		OHeight: l.OHeight,
+		ODepth:  l.ODepth,
+		Weights: l.Weights.Float32(),
+		Biases:  l.Biases.Float32(),
+	}
+}
+
+// layer32 rounds inputs to float32 and multiplies them by float32 weights.
+type layer32 struct {
+	IWidth, IHeight, IDepth int
+	OWidth, OHeight, ODepth int
+
+	Weights *data.Data32
+	Biases  *data.Data32
+
+	inputs  []float32
+	outputs []float32
+	output  *data.Data
+
+	gradInputs *data.Data
+
+	iVolume int
+	oVolume int
+
+	batchSize int
+}
+
+func (l *layer32) InitDataSizes(w, h, d int) (int, int, int) {
+	l.iVolume = w * h * d
+	l.oVolume = l.OWidth * l.OHeight * l.ODepth
+
+	if l.Weights == nil || l.oVolume < 1 || len(l.Weights.Data) != l.iVolume*l.oVolume {
+		return 0, 0, 0
+	}
+
+	l.IWidth, l.IHeight, l.IDepth = w, h, d
+	l.batchSize = 1
+
+	l.inputs = make([]float32, l.iVolume)
+	l.outputs = make([]float32, l.oVolume)
+	l.output = &data.Data{}
+	l.output.InitCube(l.OWidth, l.OHeight, l.ODepth)
+	l.gradInputs = &data.Data{}
+	l.gradInputs.InitCube(l.IWidth, l.IHeight, l.IDepth)
+
+	return l.OWidth, l.OHeight, l.ODepth
+}
+
+func (l *layer32) initBatch(n int) {
+	if l.batchSize == n {
+		return
+	}
+
+	l.batchSize = n
+	l.inputs = make([]float32, l.iVolume*n)
+	l.outputs = make([]float32, l.oVolume*n)
+	l.output.InitBatch(l.OWidth, l.OHeight, l.ODepth, n)
+	l.gradInputs.InitBatch(l.IWidth, l.IHeight, l.IDepth, n)
+}
+
+func (l *layer32) Activate(inputs *data.Data) *data.Data {
+	l.initBatch(inputs.GetBatchSize())
+
+	for i, v := range inputs.Data {
+		l.inputs[i] = float32(v)
+	}
+
+	linalg.MulTransB32(l.outputs, l.inputs, l.Weights.Data, l.batchSize, l.oVolume, l.iVolume)
+
+	for s := 0; s < l.batchSize; s++ {
+		outputs := l.outputs[s*l.oVolume : (s+1)*l.oVolume]
+		for i, b := range l.Biases.Data {
+			outputs[i] += b
+		}
+	}
+
+	for i, v := range l.outputs {
+		l.output.Data[i] = float64(v)
+	}
+
+	return l.output
+}
+
+// Backprop returns zero gradients of inputs, float32 layer is used only for inference.
+// It has no trainable parameters, so training does not change it.
+func (l *layer32) Backprop(deltas *data.Data) *data.Data {
+	return l.gradInputs
+}
+
+// Clone returns layer sharing weights, but with own buffers.
+func (l *layer32) Clone() nnet.Layer {
+	c := *l
+	c.InitDataSizes(l.IWidth, l.IHeight, l.IDepth)
+	return &c
+}
+
+func (l *layer32) GetOutput() *data.Data {
+	return l.output
+}
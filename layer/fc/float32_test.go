@@ -0,0 +1,32 @@
+package fc
+
+import (
+	"testing"
+
+	"github.com/drdreyworld/nnet/gradcheck"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLayer_Float32(t *testing.T) {
+	layer := New(OutputSizes(4, 2, 1))
+	layer.InitDataSizes(3, 3, 2)
+
+	inputs := gradcheck.Inputs(3, 3, 2, 3, 1)
+	expected := layer.Activate(inputs).Copy()
+
+	single := layer.Float32()
+
+	w, h, d := single.InitDataSizes(3, 3, 2)
+	assert.Equal(t, []int{4, 2, 1}, []int{w, h, d})
+
+	output := single.Activate(inputs)
+	assert.Equal(t, expected.Dims, output.Dims)
+	assert.InDeltaSlice(t, expected.Data, output.Data, 1e-5)
+
+	gradients := single.Backprop(output)
+	assert.Equal(t, inputs.Dims, gradients.Dims)
+	assert.Equal(t, make([]float64, len(inputs.Data)), gradients.Data, "inference only layer has zero gradients")
+
+	w, h, d = single.InitDataSizes(3, 3, 3)
+	assert.Equal(t, []int{0, 0, 0}, []int{w, h, d}, "weights do not match inputs")
+}
@@ -1,10 +1,16 @@
 package fc
 
 import (
+	"github.com/drdreyworld/nnet"
 	"github.com/drdreyworld/nnet/data"
+	"github.com/drdreyworld/nnet/linalg"
 	"math"
 )
 
+func init() {
+	nnet.RegisterLayer("fc", newFromOptions)
+}
+
 func New(options ...Option) *layer {
 	layer := &layer{}
 	defaults(layer)
@@ -31,14 +37,19 @@ type layer struct {
 	gradInputs  *data.Data
 
 	iVolume int
+	oVolume int
+
+	batchSize int
 }
 
 func (l *layer) InitDataSizes(w, h, d int) (oW, oH, oD int) {
-	l.output = &data.Data{}
-	l.output.InitCube(l.OWidth, l.OHeight, l.ODepth)
+	if l.OWidth < 1 || l.OHeight < 1 || l.ODepth < 1 {
+		return l.OWidth, l.OHeight, l.ODepth
+	}
 
 	l.IWidth, l.IHeight, l.IDepth = w, h, d
 	l.iVolume = w * h * d
+	l.oVolume = l.OWidth * l.OHeight * l.ODepth
 
 	if l.Weights == nil {
 		l.Weights = &data.Data{}
@@ -52,6 +63,18 @@ func (l *layer) InitDataSizes(w, h, d int) (oW, oH, oD int) {
 		l.Weights.InitHiperCubeRandom(l.IWidth, l.IHeight, l.IDepth, l.OWidth*l.OHeight*l.ODepth, 0, maxWeight)
 	}
 
+	l.initBuffers()
+
+	return l.OWidth, l.OHeight, l.ODepth
+}
+
+// initBuffers allocates output and gradients for the initialized sizes.
+func (l *layer) initBuffers() {
+	l.batchSize = 1
+
+	l.output = &data.Data{}
+	l.output.InitCube(l.OWidth, l.OHeight, l.ODepth)
+
 	l.gradInputs = &data.Data{}
 	l.gradInputs.InitCube(l.IWidth, l.IHeight, l.IDepth)
 
@@ -60,45 +83,68 @@ func (l *layer) InitDataSizes(w, h, d int) (oW, oH, oD int) {
 
 	l.gradWeights = &data.Data{}
 	l.gradWeights.InitHiperCube(l.IWidth, l.IHeight, l.IDepth, l.OWidth*l.OHeight*l.ODepth)
+}
 
-	return l.OWidth, l.OHeight, l.ODepth
+func (l *layer) initBatch(n int) {
+	if l.batchSize == n {
+		return
+	}
+
+	l.batchSize = n
+	l.output.InitBatch(l.OWidth, l.OHeight, l.ODepth, n)
+	l.gradInputs.InitBatch(l.IWidth, l.IHeight, l.IDepth, n)
 }
 
 func (l *layer) Activate(inputs *data.Data) *data.Data {
 	l.inputs = inputs
+	l.initBatch(inputs.GetBatchSize())
 
-	for i := 0; i < len(l.output.Data); i++ {
-		k := i * l.iVolume
-		o := 0.0
+	linalg.MulTransB(l.output.Data, l.inputs.Data, l.Weights.Data, l.batchSize, l.oVolume, l.iVolume)
 
-		for j := 0; j < len(l.inputs.Data); j++ {
-			o += l.Weights.Data[k+j] * l.inputs.Data[j]
+	for s := 0; s < l.batchSize; s++ {
+		output := l.output.Data[s*l.oVolume : (s+1)*l.oVolume]
+		for i, b := range l.Biases.Data {
+			output[i] += b
 		}
-
-		l.output.Data[i] = o + l.Biases.Data[i]
 	}
 
 	return l.output
 }
 
+// Backprop sums weights and biases gradients over the batch samples.
 func (l *layer) Backprop(deltas *data.Data) *data.Data {
 	l.gradInputs.Reset()
-	l.gradWeights.Reset()
+	l.gradBiases.Reset()
 
-	l.gradBiases = deltas.Copy()
+	linalg.MulAdd(l.gradInputs.Data, deltas.Data, l.Weights.Data, l.batchSize, l.iVolume, l.oVolume)
+	linalg.MulTransA(l.gradWeights.Data, deltas.Data, l.inputs.Data, l.oVolume, l.iVolume, l.batchSize)
 
-	for i := 0; i < len(l.output.Data); i++ {
-		k := i * l.iVolume
-		d := deltas.Data[i]
-
-		for j := 0; j < len(l.inputs.Data); j++ {
-			l.gradInputs.Data[j] += l.Weights.Data[k+j] * d
-			l.gradWeights.Data[k+j] += l.inputs.Data[j] * d
+	for s := 0; s < l.batchSize; s++ {
+		d := deltas.Data[s*l.oVolume : (s+1)*l.oVolume]
+		for i := range l.gradBiases.Data {
+			l.gradBiases.Data[i] += d[i]
 		}
 	}
+
 	return l.gradInputs
 }
 
+// Clone returns layer with the same options and shared parameters, but with own buffers.
+// Parameters of not initialized layer are created empty, so they are initialized once
+// by the first of the layers initialized.
+func (l *layer) Clone() nnet.Layer {
+	if l.Weights == nil {
+		l.Weights = &data.Data{}
+		l.Biases = &data.Data{}
+	}
+
+	c := *l
+	if l.output != nil {
+		c.initBuffers()
+	}
+	return &c
+}
+
 func (l *layer) GetOutput() *data.Data {
 	return l.output
 }
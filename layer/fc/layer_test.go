@@ -1,7 +1,9 @@
 package fc
 
 import (
+	"fmt"
 	"github.com/drdreyworld/nnet/data"
+	"github.com/drdreyworld/nnet/gradcheck"
 	"github.com/stretchr/testify/assert"
 	"testing"
 )
@@ -120,3 +122,85 @@ func TestLayer(t *testing.T) {
 	assert.Equal(t, expectedGradients, layer.Backprop(deltas))
 	assert.Equal(t, expectedGradients, layer.GetInputGradients())
 }
+
+func TestLayer_Batch(t *testing.T) {
+	layer := New(OutputSizes(2, 1, 1))
+	layer.InitDataSizes(3, 1, 1)
+
+	res := gradcheck.CheckBatch(layer, gradcheck.Inputs(3, 1, 1, 2, 1))
+	assert.Equal(t, gradcheck.BatchResult{}, res)
+}
+
+func TestLayer_Clone(t *testing.T) {
+	original := New(OutputSizes(2, 1, 1))
+	clone := original.Clone().(*layer)
+
+	clone.InitDataSizes(3, 1, 1)
+	original.InitDataSizes(3, 1, 1)
+
+	assert.Same(t, original.Weights, clone.Weights, "weights of not initialized layer are shared")
+	assert.Same(t, original.Biases, clone.Biases)
+	assert.Len(t, original.Weights.Data, 6)
+	assert.NotSame(t, original.gradWeights, clone.gradWeights)
+
+	inputs := gradcheck.Inputs(3, 1, 1, 1, 1)
+	assert.Equal(t, original.Activate(inputs), clone.Activate(inputs))
+}
+
+func TestLayer_Gradients(t *testing.T) {
+	type testCase struct {
+		iw, ih, id, n int
+		ow, oh, od    int
+	}
+	testCases := map[string]testCase{
+		"Vector": {iw: 4, ih: 1, id: 1, n: 1, ow: 3, oh: 1, od: 1},
+		"Cube":   {iw: 3, ih: 2, id: 2, n: 1, ow: 2, oh: 2, od: 1},
+		"Batch":  {iw: 3, ih: 2, id: 1, n: 3, ow: 2, oh: 1, od: 2},
+	}
+
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			layer := New(OutputSizes(tc.ow, tc.oh, tc.od))
+			layer.InitDataSizes(tc.iw, tc.ih, tc.id)
+
+			res := gradcheck.Check(layer, gradcheck.Inputs(tc.iw, tc.ih, tc.id, tc.n, 1))
+			assert.Less(t, res.Max(), 1e-7, "%+v", res)
+		})
+	}
+}
+
+func benchmarkLayer(b *testing.B, backprop bool) {
+	for _, size := range []int{2048, 4096} {
+		for _, n := range []int{1, 32} {
+			b.Run(fmt.Sprintf("%dx%d/batch%d", size, size, n), func(b *testing.B) {
+				layer := New(OutputSizes(size, 1, 1))
+				layer.InitDataSizes(size, 1, 1)
+
+				inputs := &data.Data{}
+				inputs.InitHiperCubeRandom(size, 1, 1, n, -1, 1)
+				deltas := &data.Data{}
+				deltas.InitHiperCubeRandom(size, 1, 1, n, -1, 1)
+
+				layer.Activate(inputs)
+				b.ResetTimer()
+
+				for i := 0; i < b.N; i++ {
+					if backprop {
+						layer.Backprop(deltas)
+					} else {
+						layer.Activate(inputs)
+					}
+				}
+			})
+		}
+	}
+}
+
+func BenchmarkLayer_Activate(b *testing.B) {
+	benchmarkLayer(b, false)
+}
+
+func BenchmarkLayer_Backprop(b *testing.B) {
+	benchmarkLayer(b, true)
+}
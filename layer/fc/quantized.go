@@ -0,0 +1,125 @@
+package fc
+
+import (
+	"github.com/drdreyworld/nnet"
+	"github.com/drdreyworld/nnet/data"
+)
+
+func init() {
+	nnet.RegisterLayer("fc-int8", func(options nnet.LayerOptions) (nnet.Layer, error) {
+		return &quantized{}, nil
+	})
+}
+
+// Quantize returns inference only copy of the layer with int8 weights scaled per output neuron.
+// Inputs are quantized by inputScale, see data.Int8Scale.
+func (l *layer) Quantize(inputScale float64) nnet.Layer {
+	return &quantized{
+		OWidth:     l.OWidth,
+		OHeight:    l.OHeight,
+		ODepth:     l.ODepth,
+		Weights:    l.Weights.Int8(l.OWidth * l.OHeight * l.ODepth),
+		Biases:     l.Biases.Copy(),
+		InputScale: inputScale,
+	}
+}
+
+// quantized multiplies int8 inputs by int8 weights and accumulates products in int32,
+// so inputs volume is limited by data.MaxInt8Products.
+type quantized struct {
+	IWidth, IHeight, IDepth int
+	OWidth, OHeight, ODepth int
+
+	Weights    *data.Int8
+	Biases     *data.Data
+	InputScale float64
+
+	inputs []int8
+	output *data.Data
+
+	gradInputs *data.Data
+
+	iVolume int
+	oVolume int
+
+	batchSize int
+}
+
+func (l *quantized) InitDataSizes(w, h, d int) (int, int, int) {
+	l.iVolume = w * h * d
+	l.oVolume = l.OWidth * l.OHeight * l.ODepth
+
+	if l.Weights == nil || l.oVolume < 1 || len(l.Weights.Data) != l.iVolume*l.oVolume {
+		return 0, 0, 0
+	}
+
+	if l.iVolume > data.MaxInt8Products {
+		return 0, 0, 0
+	}
+
+	l.IWidth, l.IHeight, l.IDepth = w, h, d
+	l.batchSize = 1
+
+	l.inputs = make([]int8, l.iVolume)
+	l.output = &data.Data{}
+	l.output.InitCube(l.OWidth, l.OHeight, l.ODepth)
+	l.gradInputs = &data.Data{}
+	l.gradInputs.InitCube(l.IWidth, l.IHeight, l.IDepth)
+
+	return l.OWidth, l.OHeight, l.ODepth
+}
+
+func (l *quantized) initBatch(n int) {
+	if l.batchSize == n {
+		return
+	}
+
+	l.batchSize = n
+	l.inputs = make([]int8, l.iVolume*n)
+	l.output.InitBatch(l.OWidth, l.OHeight, l.ODepth, n)
+	l.gradInputs.InitBatch(l.IWidth, l.IHeight, l.IDepth, n)
+}
+
+func (l *quantized) Activate(inputs *data.Data) *data.Data {
+	l.initBatch(inputs.GetBatchSize())
+
+	for i, v := range inputs.Data {
+		l.inputs[i] = data.QuantizeInt8(v, l.InputScale)
+	}
+
+	for i := 0; i < l.oVolume; i++ {
+		weights := l.Weights.Data[i*l.iVolume : (i+1)*l.iVolume]
+		scale := l.InputScale * l.Weights.Scales[i]
+
+		for s := 0; s < l.batchSize; s++ {
+			x := l.inputs[s*l.iVolume : (s+1)*l.iVolume]
+			x = x[:len(weights)]
+
+			var o int32
+			for j, w := range weights {
+				o += int32(w) * int32(x[j])
+			}
+
+			l.output.Data[s*l.oVolume+i] = float64(o)*scale + l.Biases.Data[i]
+		}
+	}
+
+	return l.output
+}
+
+// Backprop returns zero gradients of inputs, quantized layer is used only for inference.
+// It has no trainable parameters, so training does not change it.
+func (l *quantized) Backprop(deltas *data.Data) *data.Data {
+	return l.gradInputs
+}
+
+// Clone returns layer sharing weights, but with own buffers.
+func (l *quantized) Clone() nnet.Layer {
+	c := *l
+	c.InitDataSizes(l.IWidth, l.IHeight, l.IDepth)
+	return &c
+}
+
+func (l *quantized) GetOutput() *data.Data {
+	return l.output
+}
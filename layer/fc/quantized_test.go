@@ -0,0 +1,43 @@
+package fc
+
+import (
+	"testing"
+
+	"github.com/drdreyworld/nnet/data"
+	"github.com/drdreyworld/nnet/gradcheck"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLayer_Quantize(t *testing.T) {
+	layer := New(OutputSizes(4, 2, 1))
+	layer.InitDataSizes(3, 3, 2)
+
+	inputs := gradcheck.Inputs(3, 3, 2, 3, 1)
+	expected := layer.Activate(inputs).Copy()
+
+	quantized := layer.Quantize(data.Int8Scale(inputs.GetMaxAbsValue(0, len(inputs.Data))))
+
+	w, h, d := quantized.InitDataSizes(3, 3, 2)
+	assert.Equal(t, []int{4, 2, 1}, []int{w, h, d})
+
+	output := quantized.Activate(inputs)
+	assert.Equal(t, expected.Dims, output.Dims)
+	assert.InDeltaSlice(t, expected.Data, output.Data, 0.02)
+
+	gradients := quantized.Backprop(output)
+	assert.Equal(t, inputs.Dims, gradients.Dims)
+	assert.Equal(t, make([]float64, len(inputs.Data)), gradients.Data, "inference only layer has zero gradients")
+
+	w, h, d = quantized.InitDataSizes(3, 3, 3)
+	assert.Equal(t, []int{0, 0, 0}, []int{w, h, d}, "weights do not match inputs")
+}
+
+func TestLayer_QuantizeOverflow(t *testing.T) {
+	size := data.MaxInt8Products + 1
+
+	layer := New(OutputSizes(1, 1, 1))
+	layer.InitDataSizes(size, 1, 1)
+
+	w, h, d := layer.Quantize(1).InitDataSizes(size, 1, 1)
+	assert.Equal(t, []int{0, 0, 0}, []int{w, h, d}, "sum of products overflows int32")
+}
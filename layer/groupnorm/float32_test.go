@@ -0,0 +1,34 @@
+package groupnorm
+
+import (
+	"testing"
+
+	"github.com/drdreyworld/nnet/gradcheck"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLayer_Float32(t *testing.T) {
+	layer := New(Groups(2))
+	layer.InitDataSizes(3, 2, 4)
+	copy(layer.Weights.Data, gradcheck.Inputs(4, 1, 1, 1, 2).Data)
+	copy(layer.Biases.Data, gradcheck.Inputs(4, 1, 1, 1, 3).Data)
+
+	inputs := gradcheck.Inputs(3, 2, 4, 3, 1)
+	expected := layer.Activate(inputs).Copy()
+
+	single := layer.Float32()
+
+	w, h, d := single.InitDataSizes(3, 2, 4)
+	assert.Equal(t, []int{3, 2, 4}, []int{w, h, d})
+
+	output := single.Activate(inputs)
+	assert.Equal(t, expected.Dims, output.Dims)
+	assert.InDeltaSlice(t, expected.Data, output.Data, 1e-5)
+
+	gradients := single.Backprop(output)
+	assert.Equal(t, inputs.Dims, gradients.Dims)
+	assert.Equal(t, make([]float64, len(inputs.Data)), gradients.Data, "inference only layer has zero gradients")
+
+	w, h, d = single.InitDataSizes(3, 2, 6)
+	assert.Equal(t, []int{0, 0, 0}, []int{w, h, d}, "parameters do not match inputs")
+}
@@ -0,0 +1,208 @@
+package groupnorm
+
+import (
+	"math"
+
+	"github.com/drdreyworld/nnet"
+	"github.com/drdreyworld/nnet/data"
+)
+
+func init() {
+	nnet.RegisterLayer("groupnorm", newFromOptions)
+}
+
+func New(options ...Option) *layer {
+	layer := &layer{}
+	defaults(layer)
+
+	for _, opt := range options {
+		opt(layer)
+	}
+
+	return layer
+}
+
+// layer normalizes every sample by groups of channels, depth must be divisible by the groups count.
+// Weights and biases are per channel scale and shift.
+type layer struct {
+	IWidth, IHeight, IDepth int
+
+	Groups  int
+	Epsilon float64
+
+	Weights *data.Data
+	Biases  *data.Data
+
+	inputs *data.Data
+	output *data.Data
+	normed *data.Data
+
+	invStd []float64
+
+	gradWeights *data.Data
+	gradBiases  *data.Data
+	gradInputs  *data.Data
+
+	area      int
+	groupSize int
+	batchSize int
+}
+
+func (l *layer) InitDataSizes(w, h, d int) (int, int, int) {
+	if l.Groups < 1 || d%l.Groups != 0 {
+		return 0, 0, 0
+	}
+
+	l.IWidth, l.IHeight, l.IDepth = w, h, d
+	l.area = w * h
+	l.groupSize = l.area * d / l.Groups
+
+	if l.Weights == nil {
+		l.Weights = &data.Data{}
+		l.Biases = &data.Data{}
+	}
+
+	if len(l.Weights.Data) != d {
+		l.Weights.InitVector(d)
+		l.Weights.Fill(1)
+		l.Biases.InitVector(d)
+	}
+
+	l.initBuffers()
+
+	return w, h, d
+}
+
+// initBuffers allocates outputs and gradients for the initialized sizes.
+func (l *layer) initBuffers() {
+	l.batchSize = 1
+
+	l.output = &data.Data{}
+	l.output.InitCube(l.IWidth, l.IHeight, l.IDepth)
+	l.normed = &data.Data{}
+	l.normed.InitCube(l.IWidth, l.IHeight, l.IDepth)
+	l.gradInputs = &data.Data{}
+	l.gradInputs.InitCube(l.IWidth, l.IHeight, l.IDepth)
+
+	l.gradWeights = &data.Data{}
+	l.gradWeights.InitVector(l.IDepth)
+	l.gradBiases = &data.Data{}
+	l.gradBiases.InitVector(l.IDepth)
+
+	l.invStd = make([]float64, l.Groups)
+}
+
+func (l *layer) initBatch(n int) {
+	if l.batchSize == n {
+		return
+	}
+
+	l.batchSize = n
+	l.output.InitBatch(l.IWidth, l.IHeight, l.IDepth, n)
+	l.normed.InitBatch(l.IWidth, l.IHeight, l.IDepth, n)
+	l.gradInputs.InitBatch(l.IWidth, l.IHeight, l.IDepth, n)
+	l.invStd = make([]float64, l.Groups*n)
+}
+
+func (l *layer) Activate(inputs *data.Data) *data.Data {
+	l.inputs = inputs
+	l.initBatch(inputs.GetBatchSize())
+
+	for g := 0; g < l.Groups*l.batchSize; g++ {
+		from, to := g*l.groupSize, (g+1)*l.groupSize
+		x := inputs.Data[from:to]
+
+		mean := 0.0
+		for _, v := range x {
+			mean += v
+		}
+		mean /= float64(l.groupSize)
+
+		variance := 0.0
+		for _, v := range x {
+			variance += (v - mean) * (v - mean)
+		}
+		variance /= float64(l.groupSize)
+
+		l.invStd[g] = 1 / math.Sqrt(variance+l.Epsilon)
+
+		for i := from; i < to; i++ {
+			c := i / l.area % l.IDepth
+			l.normed.Data[i] = (inputs.Data[i] - mean) * l.invStd[g]
+			l.output.Data[i] = l.Weights.Data[c]*l.normed.Data[i] + l.Biases.Data[c]
+		}
+	}
+
+	return l.output
+}
+
+func (l *layer) Backprop(deltas *data.Data) *data.Data {
+	l.gradWeights.Reset()
+	l.gradBiases.Reset()
+
+	for g := 0; g < l.Groups*l.batchSize; g++ {
+		from, to := g*l.groupSize, (g+1)*l.groupSize
+
+		sum, sumNormed := 0.0, 0.0
+		for i := from; i < to; i++ {
+			c := i / l.area % l.IDepth
+			d := deltas.Data[i] * l.Weights.Data[c]
+
+			sum += d
+			sumNormed += d * l.normed.Data[i]
+
+			l.gradWeights.Data[c] += deltas.Data[i] * l.normed.Data[i]
+			l.gradBiases.Data[c] += deltas.Data[i]
+		}
+
+		n := float64(l.groupSize)
+		for i := from; i < to; i++ {
+			c := i / l.area % l.IDepth
+			d := deltas.Data[i] * l.Weights.Data[c]
+
+			l.gradInputs.Data[i] = l.invStd[g] * (d - (sum+l.normed.Data[i]*sumNormed)/n)
+		}
+	}
+
+	return l.gradInputs
+}
+
+// Clone returns layer with the same options and shared parameters, but with own buffers.
+// Parameters of not initialized layer are created empty, so they are initialized once
+// by the first of the layers initialized.
+func (l *layer) Clone() nnet.Layer {
+	if l.Weights == nil {
+		l.Weights = &data.Data{}
+		l.Biases = &data.Data{}
+	}
+
+	c := *l
+	if l.output != nil {
+		c.initBuffers()
+	}
+	return &c
+}
+
+func (l *layer) GetOutput() *data.Data {
+	return l.output
+}
+
+func (l *layer) GetWeights() *data.Data {
+	return l.Weights
+}
+
+func (l *layer) GetBiases() *data.Data {
+	return l.Biases
+}
+
+func (l *layer) GetWeightsWithGradient() (*data.Data, *data.Data) {
+	return l.Weights, l.gradWeights
+}
+
+func (l *layer) GetBiasesWithGradient() (*data.Data, *data.Data) {
+	return l.Biases, l.gradBiases
+}
+
+func (l *layer) GetInputGradients() *data.Data {
+	return l.gradInputs
+}
@@ -0,0 +1,60 @@
+package groupnorm
+
+import (
+	"math"
+	"testing"
+
+	"github.com/drdreyworld/nnet/data"
+	"github.com/drdreyworld/nnet/gradcheck"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLayer_InitDataSizes(t *testing.T) {
+	w, h, d := New(Groups(2)).InitDataSizes(3, 3, 4)
+	assert.Equal(t, []int{3, 3, 4}, []int{w, h, d})
+
+	w, h, d = New(Groups(3)).InitDataSizes(3, 3, 4)
+	assert.Equal(t, []int{0, 0, 0}, []int{w, h, d}, "depth is not divisible by groups")
+}
+
+func TestLayer_Activate(t *testing.T) {
+	layer := New(Groups(2), Epsilon(0))
+	layer.InitDataSizes(2, 1, 4)
+
+	layer.Weights.Data = []float64{1, 1, 2, 1}
+	layer.Biases.Data = []float64{0, 1, 0, 0}
+
+	// group 1: mean 2.5, variance 1.25, group 2: mean 0, variance 4
+	inputs := &data.Data{Dims: []int{2, 1, 4}, Data: []float64{1, 2, 3, 4, 2, -2, 2, -2}}
+	k := 1 / math.Sqrt(1.25)
+
+	assert.InDeltaSlice(t, []float64{-1.5 * k, -0.5 * k, 0.5*k + 1, 1.5*k + 1, 2, -2, 1, -1}, layer.Activate(inputs).Data, 1e-12)
+}
+
+func TestLayer_Gradients(t *testing.T) {
+	type testCase struct {
+		groups     int
+		iw, ih, id int
+		n          int
+	}
+	testCases := map[string]testCase{
+		"OneGroup":        {groups: 1, iw: 2, ih: 2, id: 3, n: 1},
+		"Groups":          {groups: 2, iw: 3, ih: 2, id: 4, n: 1},
+		"GroupPerChannel": {groups: 3, iw: 2, ih: 2, id: 3, n: 1},
+		"Batch":           {groups: 2, iw: 2, ih: 1, id: 4, n: 3},
+	}
+
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			layer := New(Groups(tc.groups))
+			layer.InitDataSizes(tc.iw, tc.ih, tc.id)
+			layer.Weights.FillRandom(0.5, 1.5)
+			layer.Biases.FillRandom(-1, 1)
+
+			// input gradients of normalization sum to zero, some of them are close to zero and compared by absolute error
+			res := gradcheck.Check(layer, gradcheck.Inputs(tc.iw, tc.ih, tc.id, tc.n, 1), gradcheck.Floor(1e-3))
+			assert.Less(t, res.Max(), 1e-6, "%+v", res)
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package groupnorm
+
+import "github.com/drdreyworld/nnet"
+
+type Option func(layer *layer)
+
+const (
+	defaultGroups  = 1
+	defaultEpsilon = 1e-5
+)
+
+func defaults(layer *layer) {
+	layer.Groups = defaultGroups
+	layer.Epsilon = defaultEpsilon
+}
+
+// Groups sets count of the channel groups, one group normalizes whole sample and
+// groups count equal to depth normalizes every channel separately.
+func Groups(count int) Option {
+	return func(layer *layer) {
+		layer.Groups = count
+	}
+}
+
+func Epsilon(epsilon float64) Option {
+	return func(layer *layer) {
+		layer.Epsilon = epsilon
+	}
+}
+
+type config struct {
+	Groups  *int
+	Epsilon *float64
+}
+
+func newFromOptions(options nnet.LayerOptions) (nnet.Layer, error) {
+	c := config{}
+	if options != nil {
+		if err := options.Decode(&c); err != nil {
+			return nil, err
+		}
+	}
+
+	layer := New()
+	if c.Groups != nil {
+		Groups(*c.Groups)(layer)
+	}
+	if c.Epsilon != nil {
+		Epsilon(*c.Epsilon)(layer)
+	}
+
+	return layer, nil
+}
@@ -0,0 +1,115 @@
+package layernorm
+
+import (
+	"math"
+
+	"github.com/drdreyworld/nnet"
+	"github.com/drdreyworld/nnet/data"
+)
+
+func init() {
+	nnet.RegisterLayer("layernorm-float32", func(options nnet.LayerOptions) (nnet.Layer, error) {
+		return &layer32{}, nil
+	})
+}
+
+// Float32 returns inference only copy of the layer with parameters and computations in float32.
+func (l *layer) Float32() nnet.Layer {
+	return &layer32{
+		Epsilon: l.Epsilon,
+		Weights: l.Weights.Float32(),
+		Biases:  l.Biases.Float32(),
+	}
+}
+
+// layer32 rounds inputs to float32 and normalizes every sample like layernorm layer.
+type layer32 struct {
+	IWidth, IHeight, IDepth int
+
+	Epsilon float64
+
+	Weights *data.Data32
+	Biases  *data.Data32
+
+	inputs []float32
+	output *data.Data
+
+	gradInputs *data.Data
+
+	volume    int
+	batchSize int
+}
+
+func (l *layer32) InitDataSizes(w, h, d int) (int, int, int) {
+	if l.Weights == nil || len(l.Weights.Data) != w*h*d {
+		return 0, 0, 0
+	}
+
+	l.IWidth, l.IHeight, l.IDepth = w, h, d
+	l.volume = w * h * d
+
+	l.initBuffers()
+
+	return w, h, d
+}
+
+func (l *layer32) initBuffers() {
+	l.inputs = make([]float32, l.volume)
+	l.output = &data.Data{}
+	l.output.InitCube(l.IWidth, l.IHeight, l.IDepth)
+	l.gradInputs = &data.Data{}
+	l.gradInputs.InitCube(l.IWidth, l.IHeight, l.IDepth)
+	l.batchSize = 1
+}
+
+func (l *layer32) Activate(inputs *data.Data) *data.Data {
+	if n := inputs.GetBatchSize(); n != l.batchSize {
+		l.batchSize = n
+		l.output.InitBatch(l.IWidth, l.IHeight, l.IDepth, n)
+		l.gradInputs.InitBatch(l.IWidth, l.IHeight, l.IDepth, n)
+	}
+
+	for s := 0; s < l.batchSize; s++ {
+		offset := s * l.volume
+
+		var mean float32
+		for i, v := range inputs.Data[offset : offset+l.volume] {
+			l.inputs[i] = float32(v)
+			mean += l.inputs[i]
+		}
+		mean /= float32(l.volume)
+
+		var variance float32
+		for _, v := range l.inputs {
+			variance += (v - mean) * (v - mean)
+		}
+		variance /= float32(l.volume)
+
+		invStd := float32(1 / math.Sqrt(float64(variance)+l.Epsilon))
+
+		for i, v := range l.inputs {
+			l.output.Data[offset+i] = float64(l.Weights.Data[i]*((v-mean)*invStd) + l.Biases.Data[i])
+		}
+	}
+
+	return l.output
+}
+
+// Backprop returns zero gradients of inputs, float32 layer is used only for inference.
+// It has no trainable parameters, so training does not change it.
+func (l *layer32) Backprop(deltas *data.Data) *data.Data {
+	return l.gradInputs
+}
+
+// Clone returns layer sharing parameters, but with own buffers.
+func (l *layer32) Clone() nnet.Layer {
+	c := *l
+	if l.output != nil {
+		c.initBuffers()
+	}
+	return &c
+}
+
+func (l *layer32) GetOutput() *data.Data {
+	return l.output
+}
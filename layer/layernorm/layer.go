@@ -0,0 +1,199 @@
+package layernorm
+
+import (
+	"math"
+
+	"github.com/drdreyworld/nnet"
+	"github.com/drdreyworld/nnet/data"
+)
+
+func init() {
+	nnet.RegisterLayer("layernorm", newFromOptions)
+}
+
+func New(options ...Option) *layer {
+	layer := &layer{}
+	defaults(layer)
+
+	for _, opt := range options {
+		opt(layer)
+	}
+
+	return layer
+}
+
+// layer normalizes every sample over all its values, so it does not depend on the batch size.
+// Weights and biases are scale and shift of every value.
+type layer struct {
+	IWidth, IHeight, IDepth int
+
+	Epsilon float64
+
+	Weights *data.Data
+	Biases  *data.Data
+
+	inputs *data.Data
+	output *data.Data
+	normed *data.Data
+
+	invStd []float64
+
+	gradWeights *data.Data
+	gradBiases  *data.Data
+	gradInputs  *data.Data
+
+	volume    int
+	batchSize int
+}
+
+func (l *layer) InitDataSizes(w, h, d int) (int, int, int) {
+	l.IWidth, l.IHeight, l.IDepth = w, h, d
+	l.volume = w * h * d
+
+	if l.Weights == nil {
+		l.Weights = &data.Data{}
+		l.Biases = &data.Data{}
+	}
+
+	if len(l.Weights.Data) != l.volume {
+		l.Weights.InitCube(w, h, d)
+		l.Weights.Fill(1)
+		l.Biases.InitCube(w, h, d)
+	}
+
+	l.initBuffers()
+
+	return w, h, d
+}
+
+// initBuffers allocates outputs and gradients for the initialized sizes.
+func (l *layer) initBuffers() {
+	l.batchSize = 1
+
+	l.output = &data.Data{}
+	l.output.InitCube(l.IWidth, l.IHeight, l.IDepth)
+	l.normed = &data.Data{}
+	l.normed.InitCube(l.IWidth, l.IHeight, l.IDepth)
+	l.gradInputs = &data.Data{}
+	l.gradInputs.InitCube(l.IWidth, l.IHeight, l.IDepth)
+
+	l.gradWeights = &data.Data{}
+	l.gradWeights.InitCube(l.IWidth, l.IHeight, l.IDepth)
+	l.gradBiases = &data.Data{}
+	l.gradBiases.InitCube(l.IWidth, l.IHeight, l.IDepth)
+
+	l.invStd = make([]float64, 1)
+}
+
+func (l *layer) initBatch(n int) {
+	if l.batchSize == n {
+		return
+	}
+
+	l.batchSize = n
+	l.output.InitBatch(l.IWidth, l.IHeight, l.IDepth, n)
+	l.normed.InitBatch(l.IWidth, l.IHeight, l.IDepth, n)
+	l.gradInputs.InitBatch(l.IWidth, l.IHeight, l.IDepth, n)
+	l.invStd = make([]float64, n)
+}
+
+func (l *layer) Activate(inputs *data.Data) *data.Data {
+	l.inputs = inputs
+	l.initBatch(inputs.GetBatchSize())
+
+	for s := 0; s < l.batchSize; s++ {
+		offset := s * l.volume
+		x := inputs.Data[offset : offset+l.volume]
+
+		mean := 0.0
+		for _, v := range x {
+			mean += v
+		}
+		mean /= float64(l.volume)
+
+		variance := 0.0
+		for _, v := range x {
+			variance += (v - mean) * (v - mean)
+		}
+		variance /= float64(l.volume)
+
+		l.invStd[s] = 1 / math.Sqrt(variance+l.Epsilon)
+
+		for i, v := range x {
+			l.normed.Data[offset+i] = (v - mean) * l.invStd[s]
+			l.output.Data[offset+i] = l.Weights.Data[i]*l.normed.Data[offset+i] + l.Biases.Data[i]
+		}
+	}
+
+	return l.output
+}
+
+func (l *layer) Backprop(deltas *data.Data) *data.Data {
+	l.gradWeights.Reset()
+	l.gradBiases.Reset()
+
+	n := float64(l.volume)
+
+	for s := 0; s < l.batchSize; s++ {
+		offset := s * l.volume
+
+		sum, sumNormed := 0.0, 0.0
+		for i := 0; i < l.volume; i++ {
+			d := deltas.Data[offset+i] * l.Weights.Data[i]
+
+			sum += d
+			sumNormed += d * l.normed.Data[offset+i]
+
+			l.gradWeights.Data[i] += deltas.Data[offset+i] * l.normed.Data[offset+i]
+			l.gradBiases.Data[i] += deltas.Data[offset+i]
+		}
+
+		for i := 0; i < l.volume; i++ {
+			d := deltas.Data[offset+i] * l.Weights.Data[i]
+
+			l.gradInputs.Data[offset+i] = l.invStd[s] * (d - (sum+l.normed.Data[offset+i]*sumNormed)/n)
+		}
+	}
+
+	return l.gradInputs
+}
+
+// Clone returns layer with the same options and shared parameters, but with own buffers.
+// Parameters of not initialized layer are created empty, so they are initialized once
+// by the first of the layers initialized.
+func (l *layer) Clone() nnet.Layer {
+	if l.Weights == nil {
+		l.Weights = &data.Data{}
+		l.Biases = &data.Data{}
+	}
+
+	c := *l
+	if l.output != nil {
+		c.initBuffers()
+	}
+	return &c
+}
+
+func (l *layer) GetOutput() *data.Data {
+	return l.output
+}
+
+func (l *layer) GetWeights() *data.Data {
+	return l.Weights
+}
+
+func (l *layer) GetBiases() *data.Data {
+	return l.Biases
+}
+
+func (l *layer) GetWeightsWithGradient() (*data.Data, *data.Data) {
+	return l.Weights, l.gradWeights
+}
+
+func (l *layer) GetBiasesWithGradient() (*data.Data, *data.Data) {
+	return l.Biases, l.gradBiases
+}
+
+func (l *layer) GetInputGradients() *data.Data {
+	return l.gradInputs
+}
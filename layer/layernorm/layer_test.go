@@ -0,0 +1,62 @@
+package layernorm
+
+import (
+	"math"
+	"testing"
+
+	"github.com/drdreyworld/nnet/data"
+	"github.com/drdreyworld/nnet/gradcheck"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLayer_Activate(t *testing.T) {
+	layer := New(Epsilon(0))
+	layer.InitDataSizes(2, 1, 2)
+
+	layer.Weights.Data = []float64{1, 2, 1, 1}
+	layer.Biases.Data = []float64{0, 0, 0, 1}
+
+	// mean 2.5, variance 1.25
+	inputs := &data.Data{Dims: []int{2, 1, 2}, Data: []float64{1, 2, 3, 4}}
+	k := 1 / math.Sqrt(1.25)
+
+	assert.InDeltaSlice(t, []float64{-1.5 * k, -k, 0.5 * k, 1.5*k + 1}, layer.Activate(inputs).Data, 1e-12)
+}
+
+func TestLayer_Gradients(t *testing.T) {
+	type testCase struct {
+		iw, ih, id int
+		n          int
+	}
+	testCases := map[string]testCase{
+		"Vector": {iw: 5, ih: 1, id: 1, n: 1},
+		"Cube":   {iw: 3, ih: 2, id: 2, n: 1},
+		"Batch":  {iw: 2, ih: 2, id: 2, n: 3},
+	}
+
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			layer := New()
+			layer.InitDataSizes(tc.iw, tc.ih, tc.id)
+			layer.Weights.FillRandom(0.5, 1.5)
+			layer.Biases.FillRandom(-1, 1)
+
+			// input gradients of normalization sum to zero, some of them are close to zero and compared by absolute error
+			res := gradcheck.Check(layer, gradcheck.Inputs(tc.iw, tc.ih, tc.id, tc.n, 1), gradcheck.Floor(1e-3))
+			assert.Less(t, res.Max(), 1e-6, "%+v", res)
+		})
+	}
+}
+
+func TestLayer_BatchIndependent(t *testing.T) {
+	layer := New()
+	layer.InitDataSizes(3, 1, 2)
+
+	a, b := gradcheck.Inputs(3, 1, 2, 1, 1), gradcheck.Inputs(3, 1, 2, 1, 2)
+
+	expected := layer.Activate(a).Copy()
+	output := layer.Activate(data.MustNewBatch(a, b))
+
+	assert.Equal(t, expected.Data, output.Data[:6])
+}
@@ -0,0 +1,37 @@
+package layernorm
+
+import "github.com/drdreyworld/nnet"
+
+type Option func(layer *layer)
+
+const defaultEpsilon = 1e-5
+
+func defaults(layer *layer) {
+	layer.Epsilon = defaultEpsilon
+}
+
+func Epsilon(epsilon float64) Option {
+	return func(layer *layer) {
+		layer.Epsilon = epsilon
+	}
+}
+
+type config struct {
+	Epsilon *float64
+}
+
+func newFromOptions(options nnet.LayerOptions) (nnet.Layer, error) {
+	c := config{}
+	if options != nil {
+		if err := options.Decode(&c); err != nil {
+			return nil, err
+		}
+	}
+
+	layer := New()
+	if c.Epsilon != nil {
+		Epsilon(*c.Epsilon)(layer)
+	}
+
+	return layer, nil
+}
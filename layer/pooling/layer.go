@@ -1,9 +1,16 @@
 package pooling
 
 import (
+	"encoding/json"
+
+	"github.com/drdreyworld/nnet"
 	"github.com/drdreyworld/nnet/data"
 )
 
+func init() {
+	nnet.RegisterLayer("pooling", newFromOptions)
+}
+
 func New(options ...Option) *layer {
 	layer := &layer{}
 	defaults(layer)
@@ -22,27 +29,57 @@ type layer struct {
 	FWidth  int
 	FHeight int
 
-	FStride  int
-	FPadding int
+	FStrideX, FStrideY int
+
+	FPaddingTop, FPaddingBottom int
+	FPaddingLeft, FPaddingRight int
+
+	// SamePadding makes output size equal to input size divided by stride,
+	// the padding is computed by InitDataSizes and the odd pixel is added to bottom and right
+	SamePadding bool
+
+	Average        bool
+	IncludePadding bool
+	Global         bool
 
 	inputs *data.Data
 	output *data.Data
 	coords []int
 
 	gradInputs *data.Data
+
+	batchSize int
 }
 
 func (l *layer) InitDataSizes(w, h, d int) (int, int, int) {
-	if l.FStride < 1 {
-		l.FStride = 1
+	if l.Global {
+		FilterSizes(w, h)(l)
+		Stride(1)(l)
+		Padding(0)(l)
+	}
+
+	if l.FStrideX < 1 {
+		l.FStrideX = 1
+	}
+	if l.FStrideY < 1 {
+		l.FStrideY = 1
 	}
 
 	l.iWidth, l.iHeight, l.iDepth = w, h, d
 
-	l.oWidth = (l.iWidth-l.FWidth+2*l.FPadding)/l.FStride + 1
-	l.oHeight = (l.iHeight-l.FHeight+2*l.FPadding)/l.FStride + 1
+	if l.SamePadding {
+		l.FPaddingLeft, l.FPaddingRight = samePadding(w, l.FWidth, l.FStrideX)
+		l.FPaddingTop, l.FPaddingBottom = samePadding(h, l.FHeight, l.FStrideY)
+	}
+
+	l.oWidth = (l.iWidth+l.FPaddingLeft+l.FPaddingRight-l.FWidth)/l.FStrideX + 1
+	l.oHeight = (l.iHeight+l.FPaddingTop+l.FPaddingBottom-l.FHeight)/l.FStrideY + 1
 	l.oDepth = l.iDepth
 
+	if l.oWidth < 1 || l.oHeight < 1 || l.oDepth < 1 {
+		return l.oWidth, l.oHeight, l.oDepth
+	}
+
 	l.output = &data.Data{}
 	l.output.InitCube(l.oWidth, l.oHeight, l.oDepth)
 
@@ -50,12 +87,29 @@ func (l *layer) InitDataSizes(w, h, d int) (int, int, int) {
 	l.gradInputs.InitCube(l.iWidth, l.iHeight, l.iDepth)
 
 	l.coords = make([]int, l.oWidth*l.oHeight*l.oDepth)
+	l.batchSize = 1
 
 	return l.oWidth, l.oHeight, l.oDepth
 }
 
+func (l *layer) initBatch(n int) {
+	if l.batchSize == n {
+		return
+	}
+
+	l.batchSize = n
+	l.output.InitBatch(l.oWidth, l.oHeight, l.oDepth, n)
+	l.gradInputs.InitBatch(l.iWidth, l.iHeight, l.iDepth, n)
+	l.coords = make([]int, l.oWidth*l.oHeight*l.oDepth*n)
+}
+
 func (l *layer) Activate(inputs *data.Data) *data.Data {
 	l.inputs = inputs
+	l.initBatch(inputs.GetBatchSize())
+
+	if l.Average {
+		return l.activateAverage()
+	}
 
 	wW, wH := l.FWidth, l.FHeight
 
@@ -64,14 +118,15 @@ func (l *layer) Activate(inputs *data.Data) *data.Data {
 
 	max := 0.0
 	maxCoord := 0
-	for oz := 0; oz < l.oDepth; oz++ {
+	// depth of the batch is depth of the sample multiplied by batch size
+	for oz := 0; oz < l.oDepth*l.batchSize; oz++ {
 		for oy := 0; oy < l.oHeight; oy++ {
 			for ox := 0; ox < l.oWidth; ox++ {
 
-				iy, n := oy*l.FStride-l.FPadding, true
+				iy, n := oy*l.FStrideY-l.FPaddingTop, true
 
 				for fy := 0; fy < wH; fy++ {
-					ix := ox*l.FStride - l.FPadding
+					ix := ox*l.FStrideX - l.FPaddingLeft
 					for fx := 0; fx < wW; fx++ {
 						if ix > -1 && ix < l.iWidth && iy > -1 && iy < l.iHeight {
 							inXYZ := oz*iSquare + iy*l.iWidth + ix
@@ -99,12 +154,117 @@ func (l *layer) Activate(inputs *data.Data) *data.Data {
 func (l *layer) Backprop(deltas *data.Data) *data.Data {
 	l.gradInputs.Reset()
 
+	if l.Average {
+		return l.backpropAverage(deltas)
+	}
+
 	for i := 0; i < len(deltas.Data); i++ {
 		l.gradInputs.Data[l.coords[i]] += deltas.Data[i]
 	}
 	return l.gradInputs
 }
 
+func (l *layer) activateAverage() *data.Data {
+	outXYZ := 0
+	for oz := 0; oz < l.oDepth*l.batchSize; oz++ {
+		for oy := 0; oy < l.oHeight; oy++ {
+			for ox := 0; ox < l.oWidth; ox++ {
+				sum := 0.0
+				count := l.window(oz, ox, oy, func(inXYZ int) {
+					sum += l.inputs.Data[inXYZ]
+				})
+
+				l.output.Data[outXYZ] = sum / count
+				outXYZ++
+			}
+		}
+	}
+	return l.output
+}
+
+func (l *layer) backpropAverage(deltas *data.Data) *data.Data {
+	outXYZ := 0
+	for oz := 0; oz < l.oDepth*l.batchSize; oz++ {
+		for oy := 0; oy < l.oHeight; oy++ {
+			for ox := 0; ox < l.oWidth; ox++ {
+				d := deltas.Data[outXYZ] / l.window(oz, ox, oy, func(int) {})
+				l.window(oz, ox, oy, func(inXYZ int) {
+					l.gradInputs.Data[inXYZ] += d
+				})
+				outXYZ++
+			}
+		}
+	}
+	return l.gradInputs
+}
+
+// window calls f with indexes of inputs of the filter window at the output position and returns
+// count of values to average, padding is counted only with IncludePadding option.
+func (l *layer) window(oz, ox, oy int, f func(inXYZ int)) float64 {
+	count := 0
+	iSquare := l.iWidth * l.iHeight
+
+	iy := oy*l.FStrideY - l.FPaddingTop
+	for fy := 0; fy < l.FHeight; fy++ {
+		ix := ox*l.FStrideX - l.FPaddingLeft
+		for fx := 0; fx < l.FWidth; fx++ {
+			if ix > -1 && ix < l.iWidth && iy > -1 && iy < l.iHeight {
+				f(oz*iSquare + iy*l.iWidth + ix)
+				count++
+			}
+			ix++
+		}
+		iy++
+	}
+
+	if l.IncludePadding || count == 0 {
+		return float64(l.FWidth * l.FHeight)
+	}
+	return float64(count)
+}
+
+// samePadding returns padding before and after the input, so the output size is input size divided by stride.
+func samePadding(size, filter, stride int) (int, int) {
+	out := (size + stride - 1) / stride
+
+	total := (out-1)*stride + filter - size
+	if total < 0 {
+		total = 0
+	}
+	return total / 2, total - total/2
+}
+
+// UnmarshalJSON also reads stride and padding of layers saved before per-axis options.
+func (l *layer) UnmarshalJSON(b []byte) error {
+	type plain layer
+	if err := json.Unmarshal(b, (*plain)(l)); err != nil {
+		return err
+	}
+
+	legacy := struct {
+		FStride  *int
+		FPadding *int
+	}{}
+	if err := json.Unmarshal(b, &legacy); err != nil {
+		return err
+	}
+
+	if legacy.FStride != nil {
+		Stride(*legacy.FStride)(l)
+	}
+	if legacy.FPadding != nil {
+		Padding(*legacy.FPadding)(l)
+	}
+	return nil
+}
+
+// Clone returns layer with the same options and shared parameters, but with own buffers.
+func (l *layer) Clone() nnet.Layer {
+	c := *l
+	c.InitDataSizes(l.iWidth, l.iHeight, l.iDepth)
+	return &c
+}
+
 func (l *layer) GetOutput() *data.Data {
 	return l.output
 }
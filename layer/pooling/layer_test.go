@@ -1,7 +1,9 @@
 package pooling
 
 import (
+	"encoding/json"
 	"github.com/drdreyworld/nnet/data"
+	"github.com/drdreyworld/nnet/gradcheck"
 	"github.com/stretchr/testify/assert"
 	"testing"
 )
@@ -75,3 +77,104 @@ func TestLayer_Backprop(t *testing.T) {
 		}),
 	)
 }
+
+func TestLayer_Batch(t *testing.T) {
+	layer := New(FilterSize(2), Stride(2))
+	layer.InitDataSizes(4, 4, 2)
+
+	res := gradcheck.CheckBatch(layer, gradcheck.Inputs(4, 4, 2, 3, 1))
+	assert.Equal(t, gradcheck.BatchResult{}, res)
+}
+
+func TestLayer_Gradients(t *testing.T) {
+	type testCase struct {
+		options    []Option
+		iw, ih, id int
+		n          int
+	}
+	testCases := map[string]testCase{
+		"Default": {options: []Option{}, iw: 4, ih: 4, id: 2, n: 1},
+		"Overlap": {options: []Option{FilterSize(3), Stride(1)}, iw: 4, ih: 5, id: 1, n: 1},
+		"Padding": {options: []Option{FilterSize(2), Stride(2), Padding(1)}, iw: 5, ih: 5, id: 1, n: 1},
+		"Batch":   {options: []Option{}, iw: 4, ih: 2, id: 2, n: 3},
+
+		"Average":               {options: []Option{Average(), FilterSize(3), Stride(1)}, iw: 4, ih: 4, id: 2, n: 1},
+		"AveragePadding":        {options: []Option{Average(), FilterSize(3), Stride(2), Padding(1)}, iw: 5, ih: 4, id: 1, n: 2},
+		"AverageIncludePadding": {options: []Option{Average(), IncludePadding(), FilterSize(3), Stride(2), Padding(1)}, iw: 5, ih: 4, id: 1, n: 2},
+		"GlobalMax":             {options: []Option{Global()}, iw: 3, ih: 4, id: 3, n: 2},
+		"GlobalAverage":         {options: []Option{Global(), Average()}, iw: 3, ih: 4, id: 3, n: 2},
+
+		"NonSquare":   {options: []Option{FilterSizes(3, 1), Strides(1, 2)}, iw: 5, ih: 4, id: 2, n: 1},
+		"Paddings":    {options: []Option{Average(), FilterSize(2), Paddings(1, 0, 0, 1)}, iw: 3, ih: 3, id: 1, n: 1},
+		"SamePadding": {options: []Option{FilterSize(3), Stride(2), SamePadding()}, iw: 6, ih: 5, id: 1, n: 2},
+	}
+
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			layer := New(tc.options...)
+			layer.InitDataSizes(tc.iw, tc.ih, tc.id)
+
+			res := gradcheck.Check(layer, gradcheck.Inputs(tc.iw, tc.ih, tc.id, tc.n, 1))
+			assert.Less(t, res.Max(), 1e-7, "%+v", res)
+		})
+	}
+}
+
+func TestLayer_Average(t *testing.T) {
+	inputs := &data.Data{}
+	inputs.InitCube(3, 3, 1)
+	inputs.Data = []float64{
+		1, 2, 3,
+		4, 5, 6,
+		7, 8, 9,
+	}
+
+	layer := New(Average(), FilterSize(2), Stride(2), Padding(1))
+	layer.InitDataSizes(3, 3, 1)
+	assert.Equal(t, []float64{1, 2.5, 5.5, 7}, layer.Activate(inputs).Data)
+
+	layer = New(Average(), IncludePadding(), FilterSize(2), Stride(2), Padding(1))
+	layer.InitDataSizes(3, 3, 1)
+	assert.Equal(t, []float64{0.25, 1.25, 2.75, 7}, layer.Activate(inputs).Data)
+}
+
+func TestLayer_Global(t *testing.T) {
+	inputs := &data.Data{}
+	inputs.InitCube(2, 2, 2)
+	inputs.Data = []float64{
+		1, 2,
+		3, 6,
+
+		-1, -2,
+		-3, -6,
+	}
+
+	layer := New(Global())
+	w, h, d := layer.InitDataSizes(2, 2, 2)
+	assert.Equal(t, []int{1, 1, 2}, []int{w, h, d})
+	assert.Equal(t, []float64{6, -1}, layer.Activate(inputs).Data)
+
+	layer = New(Global(), Average())
+	layer.InitDataSizes(2, 2, 2)
+	assert.Equal(t, []float64{3, -3}, layer.Activate(inputs).Data)
+}
+
+func TestLayer_SamePadding(t *testing.T) {
+	layer := New(FilterSizes(3, 2), Strides(2, 1), SamePadding())
+
+	w, h, _ := layer.InitDataSizes(6, 5, 1)
+	assert.Equal(t, []int{3, 5}, []int{w, h})
+	assert.Equal(t,
+		[]int{0, 1, 0, 1},
+		[]int{layer.FPaddingTop, layer.FPaddingBottom, layer.FPaddingLeft, layer.FPaddingRight},
+	)
+}
+
+func TestLayer_UnmarshalLegacyJSON(t *testing.T) {
+	layer := New()
+	assert.NoError(t, json.Unmarshal([]byte(`{"FWidth":3,"FHeight":3,"FStride":1,"FPadding":1}`), layer))
+
+	assert.Equal(t, []int{1, 1}, []int{layer.FStrideX, layer.FStrideY})
+	assert.Equal(t, []int{1, 1, 1, 1}, []int{layer.FPaddingTop, layer.FPaddingBottom, layer.FPaddingLeft, layer.FPaddingRight})
+}
@@ -1,29 +1,129 @@
 package pooling
 
+import "github.com/drdreyworld/nnet"
+
 type Option func(layer *layer)
 
 func defaults(layer *layer) {
-	layer.FWidth = 2
-	layer.FHeight = 2
-	layer.FStride = 2
-	layer.FPadding = 0
+	FilterSize(2)(layer)
+	Stride(2)(layer)
+	Padding(0)(layer)
 }
 
 func FilterSize(size int) Option {
+	return FilterSizes(size, size)
+}
+
+func FilterSizes(width, height int) Option {
 	return func(layer *layer) {
-		layer.FWidth = size
-		layer.FHeight = size
+		layer.FWidth = width
+		layer.FHeight = height
 	}
 }
 
 func Padding(padding int) Option {
+	return Paddings(padding, padding, padding, padding)
+}
+
+func Paddings(top, bottom, left, right int) Option {
 	return func(layer *layer) {
-		layer.FPadding = padding
+		layer.FPaddingTop, layer.FPaddingBottom = top, bottom
+		layer.FPaddingLeft, layer.FPaddingRight = left, right
+		layer.SamePadding = false
+	}
+}
+
+// SamePadding pads inputs so output size is input size divided by stride rounding up.
+func SamePadding() Option {
+	return func(layer *layer) {
+		layer.SamePadding = true
 	}
 }
 
 func Stride(stride int) Option {
+	return Strides(stride, stride)
+}
+
+func Strides(x, y int) Option {
 	return func(layer *layer) {
-		layer.FStride = stride
+		layer.FStrideX, layer.FStrideY = x, y
 	}
 }
+
+// Average makes layer output mean of the window inputs instead of the max one.
+func Average() Option {
+	return func(layer *layer) {
+		layer.Average = true
+	}
+}
+
+// IncludePadding makes average pooling count padding as zero inputs.
+func IncludePadding() Option {
+	return func(layer *layer) {
+		layer.IncludePadding = true
+	}
+}
+
+// Global makes filter size equal to the input width and height, so every channel is pooled to 1x1.
+func Global() Option {
+	return func(layer *layer) {
+		layer.Global = true
+	}
+}
+
+type config struct {
+	FilterSize  *int
+	FilterSizes *[2]int
+	Padding     *int
+	Paddings    *[4]int
+	SamePadding *bool
+	Stride      *int
+	Strides     *[2]int
+
+	Average        *bool
+	IncludePadding *bool
+	Global         *bool
+}
+
+func newFromOptions(options nnet.LayerOptions) (nnet.Layer, error) {
+	c := config{}
+	if options != nil {
+		if err := options.Decode(&c); err != nil {
+			return nil, err
+		}
+	}
+
+	layer := New()
+	if c.FilterSize != nil {
+		FilterSize(*c.FilterSize)(layer)
+	}
+	if c.FilterSizes != nil {
+		FilterSizes(c.FilterSizes[0], c.FilterSizes[1])(layer)
+	}
+	if c.Padding != nil {
+		Padding(*c.Padding)(layer)
+	}
+	if c.Paddings != nil {
+		Paddings(c.Paddings[0], c.Paddings[1], c.Paddings[2], c.Paddings[3])(layer)
+	}
+	if c.SamePadding != nil && *c.SamePadding {
+		SamePadding()(layer)
+	}
+	if c.Stride != nil {
+		Stride(*c.Stride)(layer)
+	}
+	if c.Strides != nil {
+		Strides(c.Strides[0], c.Strides[1])(layer)
+	}
+	if c.Average != nil {
+		layer.Average = *c.Average
+	}
+	if c.IncludePadding != nil {
+		layer.IncludePadding = *c.IncludePadding
+	}
+	if c.Global != nil {
+		layer.Global = *c.Global
+	}
+
+	return layer, nil
+}
@@ -1,10 +1,15 @@
 package softmax
 
 import (
+	"github.com/drdreyworld/nnet"
 	"github.com/drdreyworld/nnet/data"
 	"math"
 )
 
+func init() {
+	nnet.RegisterLayer("softmax", newFromOptions)
+}
+
 func New(options ...Option) *layer {
 	layer := &layer{}
 	defaults(layer)
@@ -24,6 +29,8 @@ type layer struct {
 	output *data.Data
 
 	debug bool
+
+	batchSize int
 }
 
 func (l *layer) InitDataSizes(w, h, d int) (int, int, int) {
@@ -32,6 +39,7 @@ func (l *layer) InitDataSizes(w, h, d int) (int, int, int) {
 
 	l.output = &data.Data{}
 	l.output.InitCube(w, h, d)
+	l.batchSize = 1
 
 	return l.OWidth, l.OHeight, l.ODepth
 }
@@ -39,27 +47,46 @@ func (l *layer) InitDataSizes(w, h, d int) (int, int, int) {
 func (l *layer) Activate(inputs *data.Data) *data.Data {
 	l.inputs = inputs
 
+	if n := inputs.GetBatchSize(); l.batchSize != n {
+		l.batchSize = n
+		l.output.InitBatch(l.OWidth, l.OHeight, l.ODepth, n)
+	}
+
+	cnt := l.OWidth * l.OHeight * l.ODepth
+	for s := 0; s < l.batchSize; s++ {
+		activateSample(l.inputs.Data[s*cnt:(s+1)*cnt], l.output.Data[s*cnt:(s+1)*cnt])
+	}
+
+	return l.output
+}
+
+func activateSample(inputs, output []float64) {
 	summ := 0.0
 	maxv := 0.0
 
-	cnt := len(l.inputs.Data)
+	cnt := len(inputs)
 
 	for i := 0; i < cnt; i++ {
-		if i == 0 || maxv < l.inputs.Data[i] {
-			maxv = l.inputs.Data[i]
+		if i == 0 || maxv < inputs[i] {
+			maxv = inputs[i]
 		}
 	}
 
 	for i := 0; i < cnt; i++ {
-		l.output.Data[i] = math.Exp(l.inputs.Data[i] - maxv)
-		summ += l.output.Data[i]
+		output[i] = math.Exp(inputs[i] - maxv)
+		summ += output[i]
 	}
 
 	for i := 0; i < cnt; i++ {
-		l.output.Data[i] /= summ
+		output[i] /= summ
 	}
+}
 
-	return l.output
+// Clone returns layer with the same options and shared parameters, but with own buffers.
+func (l *layer) Clone() nnet.Layer {
+	c := *l
+	c.InitDataSizes(l.IWidth, l.IHeight, l.IDepth)
+	return &c
 }
 
 func (l *layer) GetOutput() *data.Data {
@@ -2,6 +2,8 @@ package softmax
 
 import (
 	"github.com/drdreyworld/nnet/data"
+	"github.com/drdreyworld/nnet/gradcheck"
+	"github.com/drdreyworld/nnet/loss/classification"
 	"github.com/stretchr/testify/assert"
 	"math"
 	"testing"
@@ -86,3 +88,29 @@ func TestLayer_Activate(t *testing.T) {
 		})
 	}
 }
+
+func TestLayer_ActivateBatch(t *testing.T) {
+	layer := New()
+	layer.InitDataSizes(3, 1, 1)
+
+	samples := data.NewVectors([]float64{-1, 7, 3}, []float64{1, 2, 3})
+
+	expectedOutputs := []*data.Data{}
+	for i := range samples {
+		expectedOutputs = append(expectedOutputs, layer.Activate(samples[i]).Copy())
+	}
+
+	assert.Equal(t, data.MustNewBatch(expectedOutputs...), layer.Activate(data.MustNewBatch(samples...)))
+}
+
+// Backprop passes deltas through, because they are expected to be
+// classification loss deltas, so the layer is checked together with the loss.
+func TestLayer_Gradients(t *testing.T) {
+	layer := New()
+	layer.InitDataSizes(4, 1, 1)
+
+	target := data.MustNewBatch(data.NewVector(0, 1, 0, 0), data.NewVector(0, 0, 0, 1))
+
+	res := gradcheck.Check(layer, gradcheck.Inputs(4, 1, 1, 2, 1), gradcheck.WithLoss(classification.New(), target))
+	assert.Less(t, res.Max(), 1e-7, "%+v", res)
+}
@@ -1,5 +1,7 @@
 package softmax
 
+import "github.com/drdreyworld/nnet"
+
 type Option func(layer *layer)
 
 func defaults(layer *layer) {
@@ -15,3 +17,23 @@ func OutputSizes(w, h, d int) Option {
 		layer.ODepth = d
 	}
 }
+
+type config struct {
+	OutputSizes *[3]int
+}
+
+func newFromOptions(options nnet.LayerOptions) (nnet.Layer, error) {
+	c := config{}
+	if options != nil {
+		if err := options.Decode(&c); err != nil {
+			return nil, err
+		}
+	}
+
+	layer := New()
+	if c.OutputSizes != nil {
+		OutputSizes(c.OutputSizes[0], c.OutputSizes[1], c.OutputSizes[2])(layer)
+	}
+
+	return layer, nil
+}
@@ -0,0 +1,162 @@
+package upsampling
+
+import (
+	"math"
+
+	"github.com/drdreyworld/nnet"
+	"github.com/drdreyworld/nnet/data"
+)
+
+func init() {
+	nnet.RegisterLayer("upsampling", newFromOptions)
+}
+
+func New(options ...Option) *layer {
+	layer := &layer{}
+	defaults(layer)
+
+	for _, opt := range options {
+		opt(layer)
+	}
+
+	return layer
+}
+
+// layer scales width and height of every channel by integer factors. Nearest mode repeats
+// inputs, bilinear mode interpolates between centers of the input pixels.
+type layer struct {
+	iWidth, iHeight, iDepth int
+	oWidth, oHeight, oDepth int
+
+	ScaleX, ScaleY int
+	Bilinear       bool
+
+	output     *data.Data
+	gradInputs *data.Data
+
+	// xs and ys are interpolation points of output columns and rows
+	xs, ys []point
+
+	batchSize int
+}
+
+// point is interpolated between inputs from and to with weight of to.
+type point struct {
+	from, to int
+	weight   float64
+}
+
+func (l *layer) InitDataSizes(w, h, d int) (int, int, int) {
+	if l.ScaleX < 1 {
+		l.ScaleX = 1
+	}
+	if l.ScaleY < 1 {
+		l.ScaleY = 1
+	}
+
+	l.iWidth, l.iHeight, l.iDepth = w, h, d
+	l.oWidth, l.oHeight, l.oDepth = w*l.ScaleX, h*l.ScaleY, d
+
+	l.xs = l.points(w, l.ScaleX)
+	l.ys = l.points(h, l.ScaleY)
+
+	l.batchSize = 1
+	l.output = &data.Data{}
+	l.output.InitCube(l.oWidth, l.oHeight, l.oDepth)
+	l.gradInputs = &data.Data{}
+	l.gradInputs.InitCube(w, h, d)
+
+	return l.oWidth, l.oHeight, l.oDepth
+}
+
+func (l *layer) points(size, scale int) []point {
+	res := make([]point, size*scale)
+	for o := range res {
+		if !l.Bilinear {
+			res[o] = point{from: o / scale, to: o / scale}
+			continue
+		}
+
+		src := math.Max((float64(o)+0.5)/float64(scale)-0.5, 0)
+		from := int(src)
+		to := from + 1
+		if to >= size {
+			to = size - 1
+		}
+
+		res[o] = point{from: from, to: to, weight: src - float64(from)}
+	}
+	return res
+}
+
+func (l *layer) initBatch(n int) {
+	if l.batchSize == n {
+		return
+	}
+
+	l.batchSize = n
+	l.output.InitBatch(l.oWidth, l.oHeight, l.oDepth, n)
+	l.gradInputs.InitBatch(l.iWidth, l.iHeight, l.iDepth, n)
+}
+
+func (l *layer) Activate(inputs *data.Data) *data.Data {
+	l.initBatch(inputs.GetBatchSize())
+
+	iSquare, oSquare := l.iWidth*l.iHeight, l.oWidth*l.oHeight
+
+	// depth of the batch is depth of the sample multiplied by batch size
+	for z := 0; z < l.oDepth*l.batchSize; z++ {
+		in := inputs.Data[z*iSquare : (z+1)*iSquare]
+		out := l.output.Data[z*oSquare : (z+1)*oSquare]
+
+		for oy, py := range l.ys {
+			for ox, px := range l.xs {
+				top := in[py.from*l.iWidth+px.from]*(1-px.weight) + in[py.from*l.iWidth+px.to]*px.weight
+				bottom := in[py.to*l.iWidth+px.from]*(1-px.weight) + in[py.to*l.iWidth+px.to]*px.weight
+
+				out[oy*l.oWidth+ox] = top*(1-py.weight) + bottom*py.weight
+			}
+		}
+	}
+
+	return l.output
+}
+
+func (l *layer) Backprop(deltas *data.Data) *data.Data {
+	l.gradInputs.Reset()
+
+	iSquare, oSquare := l.iWidth*l.iHeight, l.oWidth*l.oHeight
+
+	for z := 0; z < l.oDepth*l.batchSize; z++ {
+		grad := l.gradInputs.Data[z*iSquare : (z+1)*iSquare]
+		d := deltas.Data[z*oSquare : (z+1)*oSquare]
+
+		for oy, py := range l.ys {
+			for ox, px := range l.xs {
+				delta := d[oy*l.oWidth+ox]
+
+				grad[py.from*l.iWidth+px.from] += delta * (1 - py.weight) * (1 - px.weight)
+				grad[py.from*l.iWidth+px.to] += delta * (1 - py.weight) * px.weight
+				grad[py.to*l.iWidth+px.from] += delta * py.weight * (1 - px.weight)
+				grad[py.to*l.iWidth+px.to] += delta * py.weight * px.weight
+			}
+		}
+	}
+
+	return l.gradInputs
+}
+
+// Clone returns layer with the same options, but with own buffers.
+func (l *layer) Clone() nnet.Layer {
+	c := *l
+	c.InitDataSizes(l.iWidth, l.iHeight, l.iDepth)
+	return &c
+}
+
+func (l *layer) GetOutput() *data.Data {
+	return l.output
+}
+
+func (l *layer) GetInputGradients() *data.Data {
+	return l.gradInputs
+}
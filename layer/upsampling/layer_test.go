@@ -0,0 +1,55 @@
+package upsampling
+
+import (
+	"testing"
+
+	"github.com/drdreyworld/nnet/data"
+	"github.com/drdreyworld/nnet/gradcheck"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLayer_Nearest(t *testing.T) {
+	layer := New(Scales(2, 3))
+
+	w, h, d := layer.InitDataSizes(2, 1, 1)
+	assert.Equal(t, []int{4, 3, 1}, []int{w, h, d})
+
+	assert.Equal(t, []float64{
+		1, 1, 2, 2,
+		1, 1, 2, 2,
+		1, 1, 2, 2,
+	}, layer.Activate(data.NewVector(1, 2)).Data)
+}
+
+func TestLayer_Bilinear(t *testing.T) {
+	layer := New(Scales(4, 1), Bilinear())
+	layer.InitDataSizes(2, 1, 1)
+
+	assert.Equal(t, []float64{1, 1, 1.125, 1.375, 1.625, 1.875, 2, 2}, layer.Activate(data.NewVector(1, 2)).Data)
+}
+
+func TestLayer_Gradients(t *testing.T) {
+	type testCase struct {
+		options    []Option
+		iw, ih, id int
+		n          int
+	}
+	testCases := map[string]testCase{
+		"Nearest":          {options: []Option{}, iw: 3, ih: 2, id: 2, n: 1},
+		"NearestScales":    {options: []Option{Scales(1, 3)}, iw: 2, ih: 2, id: 1, n: 2},
+		"Bilinear":         {options: []Option{Bilinear()}, iw: 3, ih: 3, id: 2, n: 1},
+		"BilinearScales":   {options: []Option{Bilinear(), Scales(3, 2)}, iw: 3, ih: 2, id: 1, n: 2},
+		"BilinearOnePixel": {options: []Option{Bilinear()}, iw: 1, ih: 1, id: 2, n: 1},
+	}
+
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			layer := New(tc.options...)
+			layer.InitDataSizes(tc.iw, tc.ih, tc.id)
+
+			res := gradcheck.Check(layer, gradcheck.Inputs(tc.iw, tc.ih, tc.id, tc.n, 1))
+			assert.Less(t, res.Max(), 1e-7, "%+v", res)
+		})
+	}
+}
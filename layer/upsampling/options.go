@@ -0,0 +1,54 @@
+package upsampling
+
+import "github.com/drdreyworld/nnet"
+
+type Option func(layer *layer)
+
+func defaults(layer *layer) {
+	Scale(2)(layer)
+}
+
+func Scale(scale int) Option {
+	return Scales(scale, scale)
+}
+
+func Scales(x, y int) Option {
+	return func(layer *layer) {
+		layer.ScaleX, layer.ScaleY = x, y
+	}
+}
+
+// Bilinear makes layer interpolate outputs instead of repeating the nearest input.
+func Bilinear() Option {
+	return func(layer *layer) {
+		layer.Bilinear = true
+	}
+}
+
+type config struct {
+	Scale    *int
+	Scales   *[2]int
+	Bilinear *bool
+}
+
+func newFromOptions(options nnet.LayerOptions) (nnet.Layer, error) {
+	c := config{}
+	if options != nil {
+		if err := options.Decode(&c); err != nil {
+			return nil, err
+		}
+	}
+
+	layer := New()
+	if c.Scale != nil {
+		Scale(*c.Scale)(layer)
+	}
+	if c.Scales != nil {
+		Scales(c.Scales[0], c.Scales[1])(layer)
+	}
+	if c.Bilinear != nil {
+		layer.Bilinear = *c.Bilinear
+	}
+
+	return layer, nil
+}
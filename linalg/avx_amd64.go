@@ -0,0 +1,129 @@
+package linalg
+
+// useAVX is set when the processor and the operating system support AVX.
+var useAVX = hasAVX()
+
+func hasAVX() bool
+
+// axpy4AVX adds alpha0*b0 + alpha1*b1 + alpha2*b2 + alpha3*b3 to c by four values at once,
+// len(c) must be a multiple of 4 and b must be at least as long as c.
+//
+//go:noescape
+func axpy4AVX(c, b0, b1, b2, b3 []float64, alpha0, alpha1, alpha2, alpha3 float64)
+
+// dot8x4AVX sets s[j*8+i] to dot product of the row i of a and b_j, a is 8 rows of len(b0)
+// values packed by columns.
+//
+//go:noescape
+func dot8x4AVX(s *[32]float64, a, b0, b1, b2, b3 []float64)
+
+// mulAdd4x8AVX adds to 4×8 tile of c with rows stride ldc products of 4 rows of a and k rows of b
+// with rows stride ldb, value of a for row i of c and row p of b is a[i*ai+p*ap].
+// Without load the tile is set to the products.
+//
+//go:noescape
+func mulAdd4x8AVX(c []float64, ldc int, a []float64, ai, ap int, b []float64, ldb, k int, load bool)
+
+// axpy4Asm runs axpy4 for the values of c processed by AVX and returns their count.
+func axpy4Asm(c []float64, alpha0, alpha1, alpha2, alpha3 float64, b0, b1, b2, b3 []float64) int {
+	n := len(c) &^ 3
+	if !useAVX || n == 0 {
+		return 0
+	}
+
+	axpy4AVX(c[:n], b0, b1, b2, b3, alpha0, alpha1, alpha2, alpha3)
+	return n
+}
+
+// mulTransBAsm runs MulTransB for the groups of 8 first rows of a by AVX and returns count of rows done.
+// Rows of a are packed by columns, so the kernel multiplies them by 4 rows of b at once.
+func mulTransBAsm(c, a, b []float64, m, n, k int) int {
+	groups := m / 8
+	if !useAVX || groups == 0 || k == 0 {
+		return 0
+	}
+
+	packed := make([]float64, groups*8*k)
+	for i := 0; i < groups*8; i++ {
+		g, l := i/8, i%8
+		for p, v := range a[i*k : (i+1)*k] {
+			packed[(g*k+p)*8+l] = v
+		}
+	}
+
+	cols := max(transBBlockSize/k&^3, 4)
+	s := [32]float64{}
+
+	for from := 0; from < n; from += cols {
+		to := min(from+cols, n)
+
+		for g := 0; g < groups; g++ {
+			group := packed[g*8*k : (g+1)*8*k]
+
+			j := from
+			for ; j+3 < to; j += 4 {
+				dot8x4AVX(&s, group, b[j*k:(j+1)*k], b[(j+1)*k:(j+2)*k], b[(j+2)*k:(j+3)*k], b[(j+3)*k:(j+4)*k])
+
+				for l := 0; l < 8; l++ {
+					row := c[(g*8+l)*n+j : (g*8+l)*n+j+4]
+					row[0], row[1], row[2], row[3] = s[l], s[8+l], s[16+l], s[24+l]
+				}
+			}
+			for ; j < to; j++ {
+				for i := g * 8; i < (g+1)*8; i++ {
+					c[i*n+j] = dot(a[i*k:(i+1)*k], b[j*k:(j+1)*k])
+				}
+			}
+		}
+	}
+
+	return groups * 8
+}
+
+const (
+	// kernelColsBlockSize and kernelRowsBlockSize are sizes of the block of b multiplied by all
+	// the rows of c while it stays in cache.
+	kernelColsBlockSize = 256
+	kernelRowsBlockSize = 128
+)
+
+// mulAddAsm runs mulAddRows for the tiles of the first rows and columns of c by AVX
+// and returns count of rows and columns done. Blocks of a and b are packed for the kernel,
+// so they stay in cache.
+func mulAddAsm(c, b []float64, m, n, k int, a []float64, ai, ap int, set bool) (rows, cols int) {
+	rows, cols = m&^3, n&^7
+	if !useAVX || rows == 0 || cols == 0 || k == 0 {
+		return 0, 0
+	}
+
+	packedA := make([]float64, rows*min(k, kernelRowsBlockSize))
+	packedB := make([]float64, min(k, kernelRowsBlockSize)*min(cols, kernelColsBlockSize))
+
+	for p := 0; p < k; p += kernelRowsBlockSize {
+		count := min(kernelRowsBlockSize, k-p)
+
+		for i := 0; i < rows; i++ {
+			tile := packedA[i/4*count*4:]
+			for q := 0; q < count; q++ {
+				tile[q*4+i%4] = a[i*ai+(p+q)*ap]
+			}
+		}
+
+		for from := 0; from < cols; from += kernelColsBlockSize {
+			to := min(from+kernelColsBlockSize, cols)
+
+			width := to - from
+			for q := 0; q < count; q++ {
+				copy(packedB[q*width:(q+1)*width], b[(p+q)*n+from:(p+q)*n+to])
+			}
+
+			for i := 0; i < rows; i += 4 {
+				for j := 0; j < width; j += 8 {
+					mulAdd4x8AVX(c[i*n+from+j:], n, packedA[i*count:], 1, 4, packedB[j:], width, count, !set || p > 0)
+				}
+			}
+		}
+	}
+
+	return rows, cols
+}
@@ -0,0 +1,226 @@
+#include "textflag.h"
+
+// func hasAVX() bool
+TEXT ·hasAVX(SB), NOSPLIT, $0-1
+	MOVL $1, AX
+	XORL CX, CX
+	CPUID
+	// OSXSAVE and AVX
+	ANDL $0x18000000, CX
+	CMPL CX, $0x18000000
+	JNE  no
+
+	// XMM and YMM states enabled by the operating system
+	XORL CX, CX
+	XGETBV
+	ANDL $6, AX
+	CMPL AX, $6
+	JNE  no
+
+	MOVB $1, ret+0(FP)
+	RET
+
+no:
+	MOVB $0, ret+0(FP)
+	RET
+
+// func axpy4AVX(c, b0, b1, b2, b3 []float64, alpha0, alpha1, alpha2, alpha3 float64)
+// Products are added one by one without FMA, so results are identical to the Go loop.
+TEXT ·axpy4AVX(SB), NOSPLIT, $0-152
+	MOVQ c_base+0(FP), DI
+	MOVQ c_len+8(FP), CX
+	MOVQ b0_base+24(FP), R8
+	MOVQ b1_base+48(FP), R9
+	MOVQ b2_base+72(FP), R10
+	MOVQ b3_base+96(FP), R11
+
+	VBROADCASTSD alpha0+120(FP), Y0
+	VBROADCASTSD alpha1+128(FP), Y1
+	VBROADCASTSD alpha2+136(FP), Y2
+	VBROADCASTSD alpha3+144(FP), Y3
+
+	XORQ AX, AX
+	SHRQ $2, CX
+	JZ   done
+
+loop:
+	VMOVUPD (DI)(AX*8), Y4
+	VMULPD  (R8)(AX*8), Y0, Y5
+	VADDPD  Y5, Y4, Y4
+	VMULPD  (R9)(AX*8), Y1, Y5
+	VADDPD  Y5, Y4, Y4
+	VMULPD  (R10)(AX*8), Y2, Y5
+	VADDPD  Y5, Y4, Y4
+	VMULPD  (R11)(AX*8), Y3, Y5
+	VADDPD  Y5, Y4, Y4
+	VMOVUPD Y4, (DI)(AX*8)
+
+	ADDQ $4, AX
+	DECQ CX
+	JNZ  loop
+
+done:
+	VZEROUPPER
+	RET
+
+// func dot8x4AVX(s *[32]float64, a, b0, b1, b2, b3 []float64)
+// Every value of s is a sum of products in ascending order starting from zero, like in dot.
+TEXT ·dot8x4AVX(SB), NOSPLIT, $0-128
+	MOVQ s+0(FP), DI
+	MOVQ a_base+8(FP), SI
+	MOVQ b0_base+32(FP), R8
+	MOVQ b0_len+40(FP), CX
+	MOVQ b1_base+56(FP), R9
+	MOVQ b2_base+80(FP), R10
+	MOVQ b3_base+104(FP), R11
+
+	VXORPD Y0, Y0, Y0
+	VXORPD Y1, Y1, Y1
+	VXORPD Y2, Y2, Y2
+	VXORPD Y3, Y3, Y3
+	VXORPD Y4, Y4, Y4
+	VXORPD Y5, Y5, Y5
+	VXORPD Y6, Y6, Y6
+	VXORPD Y7, Y7, Y7
+
+	XORQ  AX, AX
+	TESTQ CX, CX
+	JZ    store
+
+loop:
+	VMOVUPD (SI), Y8
+	VMOVUPD 32(SI), Y9
+
+	VBROADCASTSD (R8)(AX*8), Y10
+	VMULPD       Y8, Y10, Y11
+	VADDPD       Y11, Y0, Y0
+	VMULPD       Y9, Y10, Y12
+	VADDPD       Y12, Y1, Y1
+
+	VBROADCASTSD (R9)(AX*8), Y10
+	VMULPD       Y8, Y10, Y11
+	VADDPD       Y11, Y2, Y2
+	VMULPD       Y9, Y10, Y12
+	VADDPD       Y12, Y3, Y3
+
+	VBROADCASTSD (R10)(AX*8), Y10
+	VMULPD       Y8, Y10, Y11
+	VADDPD       Y11, Y4, Y4
+	VMULPD       Y9, Y10, Y12
+	VADDPD       Y12, Y5, Y5
+
+	VBROADCASTSD (R11)(AX*8), Y10
+	VMULPD       Y8, Y10, Y11
+	VADDPD       Y11, Y6, Y6
+	VMULPD       Y9, Y10, Y12
+	VADDPD       Y12, Y7, Y7
+
+	ADDQ $64, SI
+	INCQ AX
+	CMPQ AX, CX
+	JNE  loop
+
+store:
+	VMOVUPD Y0, (DI)
+	VMOVUPD Y1, 32(DI)
+	VMOVUPD Y2, 64(DI)
+	VMOVUPD Y3, 96(DI)
+	VMOVUPD Y4, 128(DI)
+	VMOVUPD Y5, 160(DI)
+	VMOVUPD Y6, 192(DI)
+	VMOVUPD Y7, 224(DI)
+
+	VZEROUPPER
+	RET
+
+// func mulAdd4x8AVX(c []float64, ldc int, a []float64, ai, ap int, b []float64, ldb, k int, load bool)
+// Products are added to the values of c, or to zeros without load, in ascending order of rows of b, like in axpy4.
+TEXT ·mulAdd4x8AVX(SB), NOSPLIT, $0-113
+	MOVQ c_base+0(FP), DI
+	MOVQ ldc+24(FP), DX
+	SHLQ $3, DX
+	MOVQ a_base+32(FP), R8
+	MOVQ ai+56(FP), R9
+	SHLQ $3, R9
+	LEAQ (R9)(R9*2), R12
+	MOVQ ap+64(FP), R10
+	SHLQ $3, R10
+	MOVQ b_base+72(FP), SI
+	MOVQ ldb+96(FP), R11
+	SHLQ $3, R11
+	MOVQ k+104(FP), CX
+
+	LEAQ (DI)(DX*2), R13
+
+	MOVB   load+112(FP), AX
+	TESTB  AL, AL
+	JNZ    loadtile
+	VXORPD Y0, Y0, Y0
+	VXORPD Y1, Y1, Y1
+	VXORPD Y2, Y2, Y2
+	VXORPD Y3, Y3, Y3
+	VXORPD Y4, Y4, Y4
+	VXORPD Y5, Y5, Y5
+	VXORPD Y6, Y6, Y6
+	VXORPD Y7, Y7, Y7
+	JMP    start
+
+loadtile:
+	VMOVUPD (DI), Y0
+	VMOVUPD 32(DI), Y1
+	VMOVUPD (DI)(DX*1), Y2
+	VMOVUPD 32(DI)(DX*1), Y3
+	VMOVUPD (R13), Y4
+	VMOVUPD 32(R13), Y5
+	VMOVUPD (R13)(DX*1), Y6
+	VMOVUPD 32(R13)(DX*1), Y7
+
+start:
+	TESTQ CX, CX
+	JZ    store
+
+loop:
+	VMOVUPD (SI), Y8
+	VMOVUPD 32(SI), Y9
+	ADDQ    R11, SI
+
+	VBROADCASTSD (R8), Y10
+	VMULPD       Y8, Y10, Y11
+	VADDPD       Y11, Y0, Y0
+	VMULPD       Y9, Y10, Y12
+	VADDPD       Y12, Y1, Y1
+
+	VBROADCASTSD (R8)(R9*1), Y10
+	VMULPD       Y8, Y10, Y11
+	VADDPD       Y11, Y2, Y2
+	VMULPD       Y9, Y10, Y12
+	VADDPD       Y12, Y3, Y3
+
+	VBROADCASTSD (R8)(R9*2), Y10
+	VMULPD       Y8, Y10, Y11
+	VADDPD       Y11, Y4, Y4
+	VMULPD       Y9, Y10, Y12
+	VADDPD       Y12, Y5, Y5
+
+	VBROADCASTSD (R8)(R12*1), Y10
+	VMULPD       Y8, Y10, Y11
+	VADDPD       Y11, Y6, Y6
+	VMULPD       Y9, Y10, Y12
+	VADDPD       Y12, Y7, Y7
+
+	ADDQ R10, R8
+	DECQ CX
+	JNZ  loop
+
+store:
+	VMOVUPD Y0, (DI)
+	VMOVUPD Y1, 32(DI)
+	VMOVUPD Y2, (DI)(DX*1)
+	VMOVUPD Y3, 32(DI)(DX*1)
+	VMOVUPD Y4, (R13)
+	VMOVUPD Y5, 32(R13)
+	VMOVUPD Y6, (R13)(DX*1)
+	VMOVUPD Y7, 32(R13)(DX*1)
+
+	VZEROUPPER
+	RET
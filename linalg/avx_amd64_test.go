@@ -0,0 +1,19 @@
+package linalg
+
+import "testing"
+
+func TestWithoutAVX(t *testing.T) {
+	if !useAVX {
+		t.Skip("AVX is not supported")
+	}
+
+	useAVX = false
+	defer func() {
+		useAVX = true
+	}()
+
+	t.Run("MulTransB", TestMulTransB)
+	t.Run("MulAdd", TestMulAdd)
+	t.Run("MulTransA", TestMulTransA)
+	t.Run("MulTransAAdd", TestMulTransAAdd)
+}
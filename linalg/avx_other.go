@@ -0,0 +1,16 @@
+//go:build !amd64
+// +build !amd64
+
+package linalg
+
+func axpy4Asm(c []float64, alpha0, alpha1, alpha2, alpha3 float64, b0, b1, b2, b3 []float64) int {
+	return 0
+}
+
+func mulTransBAsm(c, a, b []float64, m, n, k int) int {
+	return 0
+}
+
+func mulAddAsm(c, b []float64, m, n, k int, a []float64, ai, ap int, set bool) (rows, cols int) {
+	return 0, 0
+}
@@ -0,0 +1,49 @@
+package linalg
+
+// MulTransB32 sets c (m×n) to a (m×k) multiplied by transposed b (n×k) in float32.
+func MulTransB32(c, a, b []float32, m, n, k int) {
+	for i := 0; i < m; i++ {
+		a0 := a[i*k : (i+1)*k]
+		c0 := c[i*n : (i+1)*n]
+
+		for j := range c0 {
+			c0[j] = dot32(a0, b[j*k:(j+1)*k])
+		}
+	}
+}
+
+// MulAdd32 adds a (m×k) multiplied by b (k×n) to c (m×n) in float32.
+func MulAdd32(c, a, b []float32, m, n, k int) {
+	for from := 0; from < n; from += blockSize {
+		to := min(from+blockSize, n)
+
+		for rowsFrom := 0; rowsFrom < k; rowsFrom += rowsBlockSize {
+			rowsTo := min(rowsFrom+rowsBlockSize, k)
+
+			for i := 0; i < m; i++ {
+				block := c[i*n+from : i*n+to]
+
+				for p := rowsFrom; p < rowsTo; p++ {
+					axpy32(block, a[i*k+p], b[p*n+from:p*n+to])
+				}
+			}
+		}
+	}
+}
+
+func dot32(a, b []float32) float32 {
+	b = b[:len(a)]
+
+	var s float32
+	for i, v := range a {
+		s += v * b[i]
+	}
+	return s
+}
+
+func axpy32(c []float32, alpha float32, b []float32) {
+	b = b[:len(c)]
+	for i := range c {
+		c[i] += alpha * b[i]
+	}
+}
@@ -0,0 +1,61 @@
+package linalg
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func random32(r *rand.Rand, size int) []float32 {
+	res := make([]float32, size)
+	for i := range res {
+		res[i] = r.Float32()*2 - 1
+	}
+	return res
+}
+
+func TestMulTransB32(t *testing.T) {
+	for name, s := range testSizes {
+		s := s
+		t.Run(name, func(t *testing.T) {
+			r := rand.New(rand.NewSource(1))
+			a, b := random32(r, s.m*s.k), random32(r, s.n*s.k)
+
+			expected := make([]float32, s.m*s.n)
+			for i := 0; i < s.m; i++ {
+				for j := 0; j < s.n; j++ {
+					for p := 0; p < s.k; p++ {
+						expected[i*s.n+j] += a[i*s.k+p] * b[j*s.k+p]
+					}
+				}
+			}
+
+			c := random32(r, s.m*s.n)
+			MulTransB32(c, a, b, s.m, s.n, s.k)
+			assert.Equal(t, expected, c)
+		})
+	}
+}
+
+func TestMulAdd32(t *testing.T) {
+	for name, s := range testSizes {
+		s := s
+		t.Run(name, func(t *testing.T) {
+			r := rand.New(rand.NewSource(1))
+			a, b, c := random32(r, s.m*s.k), random32(r, s.k*s.n), random32(r, s.m*s.n)
+
+			expected := append([]float32{}, c...)
+			for i := 0; i < s.m; i++ {
+				for j := 0; j < s.n; j++ {
+					for p := 0; p < s.k; p++ {
+						expected[i*s.n+j] += a[i*s.k+p] * b[p*s.n+j]
+					}
+				}
+			}
+
+			MulAdd32(c, a, b, s.m, s.n, s.k)
+			assert.Equal(t, expected, c)
+		})
+	}
+}
@@ -0,0 +1,215 @@
+// Package linalg implements matrix multiplication kernels for row-major float64 and float32 matrices.
+//
+// Kernels are blocked and unrolled, on amd64 with AVX float64 tiles are computed by assembly kernels
+// without FMA. Every element sums its products in ascending order of the inner dimension starting
+// from zero or from the value of c, so results are identical to the naive triple loop.
+package linalg
+
+// MulTransB sets c (m×n) to a (m×k) multiplied by transposed b (n×k).
+// Rows of b are taken by blocks staying in cache while they are multiplied by every row of a.
+// Groups of rows of a are multiplied by SIMD kernel if it is available, see mulTransBAsm.
+func MulTransB(c, a, b []float64, m, n, k int) {
+	rows := mulTransBAsm(c, a, b, m, n, k)
+	a, c, m = a[rows*k:], c[rows*n:], m-rows
+
+	cols := transBBlockSize / max(k, 1) &^ 3
+	cols = max(cols, 4)
+
+	for from := 0; from < n; from += cols {
+		to := min(from+cols, n)
+
+		i := 0
+		for ; i+1 < m; i += 2 {
+			a0, a1 := a[i*k:(i+1)*k], a[(i+1)*k:(i+2)*k]
+			c0, c1 := c[i*n:(i+1)*n], c[(i+1)*n:(i+2)*n]
+
+			j := from
+			for ; j+3 < to; j += 4 {
+				c0[j], c0[j+1], c0[j+2], c0[j+3], c1[j], c1[j+1], c1[j+2], c1[j+3] = dot2x4(
+					a0, a1,
+					b[j*k:(j+1)*k], b[(j+1)*k:(j+2)*k], b[(j+2)*k:(j+3)*k], b[(j+3)*k:(j+4)*k],
+				)
+			}
+			for ; j < to; j++ {
+				c0[j] = dot(a0, b[j*k:(j+1)*k])
+				c1[j] = dot(a1, b[j*k:(j+1)*k])
+			}
+		}
+
+		for ; i < m; i++ {
+			a0 := a[i*k : (i+1)*k]
+			c0 := c[i*n : (i+1)*n]
+
+			j := from
+			for ; j+3 < to; j += 4 {
+				c0[j], c0[j+1], c0[j+2], c0[j+3] = dot1x4(
+					a0,
+					b[j*k:(j+1)*k], b[(j+1)*k:(j+2)*k], b[(j+2)*k:(j+3)*k], b[(j+3)*k:(j+4)*k],
+				)
+			}
+			for ; j < to; j++ {
+				c0[j] = dot(a0, b[j*k:(j+1)*k])
+			}
+		}
+	}
+}
+
+// MulAdd adds a (m×k) multiplied by b (k×n) to c (m×n).
+func MulAdd(c, a, b []float64, m, n, k int) {
+	mulAddRows(c, b, m, n, k, a, k, 1, false)
+}
+
+// MulTransAAdd adds transposed a (k×m) multiplied by b (k×n) to c (m×n).
+func MulTransAAdd(c, a, b []float64, m, n, k int) {
+	mulAddRows(c, b, m, n, k, a, 1, m, false)
+}
+
+// MulTransA sets c (m×n) to transposed a (k×m) multiplied by b (k×n), like MulTransAAdd of zeros
+// but without passing c to reset it.
+func MulTransA(c, a, b []float64, m, n, k int) {
+	mulAddRows(c, b, m, n, k, a, 1, m, true)
+}
+
+const (
+	// transBBlockSize is count of values of b rows multiplied by every row of a while they stay in cache.
+	transBBlockSize = 16 * 1024
+
+	// blockSize is count of columns of c updated by rowsBlockSize rows of b while they stay in cache.
+	blockSize     = 1024
+	rowsBlockSize = 32
+)
+
+// mulAddRows adds to every row i of c rows p of b (k×n) multiplied by coefficients a[i*ai+p*ap],
+// or sets c to the sum of the products with set. Tiles of c are added by SIMD kernel if it is available,
+// see mulAddAsm, the rest is added by mulAddBlock.
+func mulAddRows(c, b []float64, m, n, k int, a []float64, ai, ap int, set bool) {
+	if set && k == 0 {
+		for i := range c[:m*n] {
+			c[i] = 0
+		}
+		return
+	}
+
+	rows, cols := mulAddAsm(c, b, m, n, k, a, ai, ap, set)
+
+	mulAddBlock(c, b, 0, rows, cols, n, n, k, a, ai, ap, set)
+	mulAddBlock(c, b, rows, m, 0, n, n, k, a, ai, ap, set)
+}
+
+// mulAddBlock runs mulAddRows for the rows and columns of c in the ranges,
+// four rows of b are added per pass over the block of c columns.
+func mulAddBlock(c, b []float64, rowsFrom, rowsTo, colsFrom, colsTo, n, k int, a []float64, ai, ap int, set bool) {
+	for from := colsFrom; from < colsTo; from += blockSize {
+		to := min(from+blockSize, colsTo)
+
+		for pFrom := 0; pFrom < k; pFrom += rowsBlockSize {
+			pTo := min(pFrom+rowsBlockSize, k)
+
+			for i := rowsFrom; i < rowsTo; i++ {
+				block := c[i*n+from : i*n+to]
+
+				p := pFrom
+				if set && p == 0 {
+					scale(block, a[i*ai], b[from:to])
+					p++
+				}
+
+				for ; p+3 < pTo; p += 4 {
+					axpy4(block,
+						a[i*ai+p*ap], a[i*ai+(p+1)*ap], a[i*ai+(p+2)*ap], a[i*ai+(p+3)*ap],
+						b[p*n+from:p*n+to], b[(p+1)*n+from:(p+1)*n+to], b[(p+2)*n+from:(p+2)*n+to], b[(p+3)*n+from:(p+3)*n+to],
+					)
+				}
+				for ; p < pTo; p++ {
+					axpy(block, a[i*ai+p*ap], b[p*n+from:p*n+to])
+				}
+			}
+		}
+	}
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+func dot(a, b []float64) float64 {
+	b = b[:len(a)]
+
+	s := 0.0
+	for i, v := range a {
+		s += v * b[i]
+	}
+	return s
+}
+
+func dot1x4(a, b0, b1, b2, b3 []float64) (s0, s1, s2, s3 float64) {
+	b0, b1, b2, b3 = b0[:len(a)], b1[:len(a)], b2[:len(a)], b3[:len(a)]
+
+	for i, v := range a {
+		s0 += v * b0[i]
+		s1 += v * b1[i]
+		s2 += v * b2[i]
+		s3 += v * b3[i]
+	}
+	return
+}
+
+func dot2x4(a0, a1, b0, b1, b2, b3 []float64) (s00, s01, s02, s03, s10, s11, s12, s13 float64) {
+	a1 = a1[:len(a0)]
+	b0, b1, b2, b3 = b0[:len(a0)], b1[:len(a0)], b2[:len(a0)], b3[:len(a0)]
+
+	for i, x0 := range a0 {
+		x1 := a1[i]
+		y0, y1, y2, y3 := b0[i], b1[i], b2[i], b3[i]
+
+		s00 += x0 * y0
+		s01 += x0 * y1
+		s02 += x0 * y2
+		s03 += x0 * y3
+		s10 += x1 * y0
+		s11 += x1 * y1
+		s12 += x1 * y2
+		s13 += x1 * y3
+	}
+	return
+}
+
+func axpy(c []float64, alpha float64, b []float64) {
+	b = b[:len(c)]
+	for i := range c {
+		c[i] += alpha * b[i]
+	}
+}
+
+// scale sets c to alpha*b, values are added to zero like in axpy.
+func scale(c []float64, alpha float64, b []float64) {
+	b = b[:len(c)]
+	for i := range c {
+		c[i] = 0 + alpha*b[i]
+	}
+}
+
+func axpy4(c []float64, alpha0, alpha1, alpha2, alpha3 float64, b0, b1, b2, b3 []float64) {
+	b0, b1, b2, b3 = b0[:len(c)], b1[:len(c)], b2[:len(c)], b3[:len(c)]
+
+	from := axpy4Asm(c, alpha0, alpha1, alpha2, alpha3, b0, b1, b2, b3)
+	c, b0, b1, b2, b3 = c[from:], b0[from:], b1[from:], b2[from:], b3[from:]
+
+	for i, v := range c {
+		v += alpha0 * b0[i]
+		v += alpha1 * b1[i]
+		v += alpha2 * b2[i]
+		v += alpha3 * b3[i]
+		c[i] = v
+	}
+}
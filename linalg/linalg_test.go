@@ -0,0 +1,157 @@
+package linalg
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func random(r *rand.Rand, size int) []float64 {
+	res := make([]float64, size)
+	for i := range res {
+		res[i] = r.Float64()*2 - 1
+	}
+	return res
+}
+
+type sizes struct {
+	m, n, k int
+}
+
+var testSizes = map[string]sizes{
+	"Vector":    {m: 1, n: 1, k: 7},
+	"Row":       {m: 1, n: 9, k: 5},
+	"Column":    {m: 6, n: 1, k: 3},
+	"Even":      {m: 4, n: 8, k: 16},
+	"Remainder": {m: 5, n: 7, k: 11},
+	"Blocks":    {m: 3, n: 2*blockSize + 5, k: 6},
+	"Groups":    {m: 19, n: 10, k: 13},
+	"Empty":     {m: 9, n: 5, k: 0},
+	"Tiles":     {m: 9, n: 521, k: 300},
+}
+
+func TestMulTransB(t *testing.T) {
+	for name, s := range testSizes {
+		s := s
+		t.Run(name, func(t *testing.T) {
+			r := rand.New(rand.NewSource(1))
+			a, b := random(r, s.m*s.k), random(r, s.n*s.k)
+
+			expected := make([]float64, s.m*s.n)
+			for i := 0; i < s.m; i++ {
+				for j := 0; j < s.n; j++ {
+					for p := 0; p < s.k; p++ {
+						expected[i*s.n+j] += a[i*s.k+p] * b[j*s.k+p]
+					}
+				}
+			}
+
+			c := random(r, s.m*s.n)
+			MulTransB(c, a, b, s.m, s.n, s.k)
+			assert.Equal(t, expected, c)
+		})
+	}
+}
+
+func TestMulAdd(t *testing.T) {
+	for name, s := range testSizes {
+		s := s
+		t.Run(name, func(t *testing.T) {
+			r := rand.New(rand.NewSource(1))
+			a, b, c := random(r, s.m*s.k), random(r, s.k*s.n), random(r, s.m*s.n)
+
+			expected := append([]float64{}, c...)
+			for i := 0; i < s.m; i++ {
+				for j := 0; j < s.n; j++ {
+					for p := 0; p < s.k; p++ {
+						expected[i*s.n+j] += a[i*s.k+p] * b[p*s.n+j]
+					}
+				}
+			}
+
+			MulAdd(c, a, b, s.m, s.n, s.k)
+			assert.Equal(t, expected, c)
+		})
+	}
+}
+
+func TestMulTransAAdd(t *testing.T) {
+	for name, s := range testSizes {
+		s := s
+		t.Run(name, func(t *testing.T) {
+			r := rand.New(rand.NewSource(1))
+			a, b, c := random(r, s.k*s.m), random(r, s.k*s.n), random(r, s.m*s.n)
+
+			expected := append([]float64{}, c...)
+			for i := 0; i < s.m; i++ {
+				for j := 0; j < s.n; j++ {
+					for p := 0; p < s.k; p++ {
+						expected[i*s.n+j] += a[p*s.m+i] * b[p*s.n+j]
+					}
+				}
+			}
+
+			MulTransAAdd(c, a, b, s.m, s.n, s.k)
+			assert.Equal(t, expected, c)
+		})
+	}
+}
+
+func TestMulTransA(t *testing.T) {
+	for name, s := range testSizes {
+		s := s
+		t.Run(name, func(t *testing.T) {
+			r := rand.New(rand.NewSource(1))
+			a, b := random(r, s.k*s.m), random(r, s.k*s.n)
+
+			expected := make([]float64, s.m*s.n)
+			for i := 0; i < s.m; i++ {
+				for j := 0; j < s.n; j++ {
+					for p := 0; p < s.k; p++ {
+						expected[i*s.n+j] += a[p*s.m+i] * b[p*s.n+j]
+					}
+				}
+			}
+
+			c := random(r, s.m*s.n)
+			MulTransA(c, a, b, s.m, s.n, s.k)
+			assert.Equal(t, expected, c)
+		})
+	}
+}
+
+func benchmark(b *testing.B, f func(c, a, b []float64, m, n, k int), m, n, k int) {
+	r := rand.New(rand.NewSource(1))
+	x, y, c := random(r, m*k), random(r, n*k), random(r, m*n)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		f(c, x, y, m, n, k)
+	}
+}
+
+func BenchmarkMulTransB(b *testing.B) {
+	for _, s := range []sizes{{1, 2048, 2048}, {32, 2048, 2048}, {1, 4096, 4096}, {32, 4096, 4096}} {
+		b.Run(fmt.Sprintf("%dx%dx%d", s.m, s.n, s.k), func(b *testing.B) {
+			benchmark(b, MulTransB, s.m, s.n, s.k)
+		})
+	}
+}
+
+func BenchmarkMulAdd(b *testing.B) {
+	for _, s := range []sizes{{1, 2048, 2048}, {32, 2048, 2048}, {1, 4096, 4096}, {32, 4096, 4096}} {
+		b.Run(fmt.Sprintf("%dx%dx%d", s.m, s.n, s.k), func(b *testing.B) {
+			benchmark(b, MulAdd, s.m, s.n, s.k)
+		})
+	}
+}
+
+func BenchmarkMulTransA(b *testing.B) {
+	for _, s := range []sizes{{2048, 2048, 1}, {2048, 2048, 32}, {4096, 4096, 1}, {4096, 4096, 32}} {
+		b.Run(fmt.Sprintf("%dx%dx%d", s.m, s.n, s.k), func(b *testing.B) {
+			benchmark(b, MulTransA, s.m, s.n, s.k)
+		})
+	}
+}
@@ -26,10 +26,12 @@ func (c *loss) GetError(target, output []float64) float64 {
 	return 0
 }
 
+// GetDeltas returns deltas averaged over the batch samples.
 func (c *loss) GetDeltas(target, output *data.Data) (res *data.Data) {
 	res = output.Copy()
+	n := float64(output.GetBatchSize())
 	for i := 0; i < len(target.Data); i++ {
-		res.Data[i] = output.Data[i] - target.Data[i]
+		res.Data[i] = (output.Data[i] - target.Data[i]) / n
 	}
 	return
 }
@@ -18,10 +18,12 @@ func (c *loss) GetError(target, result []float64) (res float64) {
 	return 0.5 * res
 }
 
+// GetDeltas returns deltas averaged over the batch samples.
 func (c *loss) GetDeltas(target, output *data.Data) (res *data.Data) {
 	res = output.CopyZero()
+	n := float64(output.GetBatchSize())
 	for i := 0; i < len(target.Data); i++ {
-		res.Data[i] = output.Data[i] - target.Data[i]
+		res.Data[i] = (output.Data[i] - target.Data[i]) / n
 	}
 	return
 }
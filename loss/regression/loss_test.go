@@ -25,6 +25,11 @@ func TestLoss_GetDeltas(t *testing.T) {
 			output:   data.NewVector(1.4, -0.7, 0.3),
 			expected: data.NewVector(1.4, -1.7, 0.3),
 		},
+		"Batch": {
+			target:   data.MustNewBatch(data.NewVector(0.0, 1.0), data.NewVector(1.0, 0.0)),
+			output:   data.MustNewBatch(data.NewVector(0.5, 0.7), data.NewVector(0.2, 0.4)),
+			expected: &data.Data{Dims: []int{2, 1, 1, 2}, Data: []float64{0.25, -0.15000000000000002, -0.4, 0.2}},
+		},
 	}
 
 	for name, tc := range testCases {
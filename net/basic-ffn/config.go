@@ -0,0 +1,126 @@
+package basic_ffn
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	"github.com/drdreyworld/nnet"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+var ErrorLayerTypeMissing = errors.New("layer type missing")
+
+// Config is a declarative network definition, for example:
+//
+//	input: {width: 28, height: 28, depth: 1}
+//	layers:
+//	  - type: conv
+//	    options: {filterSize: 5, filtersCount: 8, stride: 1, padding: 2}
+//	  - type: activation
+//	    options: {func: elu, k: 0.5}
+//	  - type: pooling
+//	    options: {filterSize: 2, stride: 2}
+//	  - type: fc
+//	    options: {outputSizes: [10, 1, 1]}
+//	  - type: softmax
+type Config struct {
+	Input struct {
+		Width, Height, Depth int
+	}
+	Layers []LayerConfig
+}
+
+type LayerConfig struct {
+	Type    string
+	Options json.RawMessage
+}
+
+type layerOptions json.RawMessage
+
+// Decode rejects unknown options, so a misspelled option is not replaced by its default silently.
+func (o layerOptions) Decode(v interface{}) error {
+	if len(o) == 0 {
+		return nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(o))
+	decoder.DisallowUnknownFields()
+	return decoder.Decode(v)
+}
+
+// NewFromConfig creates and initializes a network from the definition.
+func NewFromConfig(config Config) (*ffnet, error) {
+	layers := make(Layers, len(config.Layers))
+	for i, lc := range config.Layers {
+		if lc.Type == "" {
+			return nil, errors.Wrap(ErrorLayerTypeMissing, fmt.Sprintf("layer %d", i))
+		}
+
+		layer, err := nnet.NewLayer(lc.Type, layerOptions(lc.Options))
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("layer %d", i))
+		}
+
+		layers[i] = layer
+	}
+
+	n := New(config.Input.Width, config.Input.Height, config.Input.Depth, layers)
+	if err := n.Init(); err != nil {
+		return nil, err
+	}
+	return n, nil
+}
+
+// NewFromJSON decodes the definition rejecting unknown keys, options of layers are decoded by the layers.
+func NewFromJSON(r io.Reader) (*ffnet, error) {
+	decoder := json.NewDecoder(r)
+	decoder.DisallowUnknownFields()
+
+	config := Config{}
+	if err := decoder.Decode(&config); err != nil {
+		return nil, err
+	}
+	return NewFromConfig(config)
+}
+
+func NewFromYAML(r io.Reader) (*ffnet, error) {
+	b, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var v interface{}
+	if err := yaml.Unmarshal(b, &v); err != nil {
+		return nil, err
+	}
+
+	// layer options are decoded with encoding/json, so yaml maps are converted to json ones first
+	b, err = json.Marshal(yamlToJSON(v))
+	if err != nil {
+		return nil, err
+	}
+
+	return NewFromJSON(bytes.NewReader(b))
+}
+
+func yamlToJSON(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[interface{}]interface{}:
+		res := make(map[string]interface{}, len(v))
+		for key, value := range v {
+			res[fmt.Sprint(key)] = yamlToJSON(value)
+		}
+		return res
+	case []interface{}:
+		res := make([]interface{}, len(v))
+		for i, value := range v {
+			res[i] = yamlToJSON(value)
+		}
+		return res
+	}
+	return v
+}
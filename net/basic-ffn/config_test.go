@@ -0,0 +1,136 @@
+package basic_ffn
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/drdreyworld/nnet"
+	"github.com/drdreyworld/nnet/data"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewFromYAML(t *testing.T) {
+	net, err := NewFromYAML(strings.NewReader(`
+input: {width: 8, height: 8, depth: 1}
+layers:
+  - type: conv
+    options: {filterSize: 3, filtersCount: 4, padding: 1}
+  - type: activation
+    options: {func: elu, k: 0.5}
+  - type: pooling
+    options: {filterSize: 2, stride: 2}
+  - type: fc
+    options: {outputSizes: [10, 1, 1]}
+  - type: softmax
+`))
+	assert.NoError(t, err)
+	assert.Equal(t, 5, net.GetLayersCount())
+	assert.Equal(t, []int{10, 1, 1}, []int{net.oWidth, net.oHeight, net.oDepth})
+
+	inputs := &data.Data{}
+	inputs.InitCubeRandom(8, 8, 1, 0, 1)
+	assert.Equal(t, 10, len(net.Activate(inputs).Data))
+
+	saved := &bytes.Buffer{}
+	assert.NoError(t, net.Save(saved))
+	assert.Contains(t, saved.String(), `"Func":"elu","Params":{"K":0.5}`)
+}
+
+func TestNewFromYAML_SeparableSaveLoad(t *testing.T) {
+	net, err := NewFromYAML(strings.NewReader(`
+input: {width: 6, height: 6, depth: 2}
+layers:
+  - type: separable
+    options: {filtersCount: 4, multiplier: 2, filterSize: 3, samePadding: true}
+  - type: conv
+    options: {filterSizes: [1, 3], filtersCount: 2, groups: 2, strides: [2, 1]}
+`))
+	assert.NoError(t, err)
+	assert.Equal(t, []int{3, 4, 2}, []int{net.oWidth, net.oHeight, net.oDepth})
+
+	inputs := &data.Data{}
+	inputs.InitCubeRandom(6, 6, 2, -1, 1)
+	expected := net.Activate(inputs).Copy()
+
+	saved := &bytes.Buffer{}
+	assert.NoError(t, net.Save(saved))
+
+	loaded := New(0, 0, 0, nil)
+	assert.NoError(t, loaded.Load(saved))
+	assert.Equal(t, expected, loaded.Activate(inputs))
+}
+
+func TestNewFromJSON(t *testing.T) {
+	net, err := NewFromJSON(strings.NewReader(`{
+	"input": {"width": 4, "height": 1, "depth": 1},
+	"layers": [
+		{"type": "fc", "options": {"outputSizes": [3, 1, 1]}},
+		{"type": "activation", "options": {"func": "sigmoid"}}
+	]
+}`))
+	assert.NoError(t, err)
+	assert.Equal(t, []int{3, 1, 1}, []int{net.oWidth, net.oHeight, net.oDepth})
+}
+
+func TestNewFromConfigErrors(t *testing.T) {
+	type testCase struct {
+		config   string
+		expected error
+		message  string
+	}
+
+	testCases := map[string]testCase{
+		"invalidInput": {
+			config:   `{"input": {"width": 0, "height": 1, "depth": 1}}`,
+			expected: ErrorInvalidDataSizes,
+			message:  "input: 0x1x1: invalid data sizes",
+		},
+		"filterBiggerThanInput": {
+			config: `{"input": {"width": 4, "height": 4, "depth": 1}, "layers": [
+				{"type": "conv", "options": {"filterSize": 3}},
+				{"type": "conv", "options": {"filterSize": 5}}
+			]}`,
+			expected: ErrorInvalidDataSizes,
+			message:  "layer 1 output: -2x-2x1: invalid data sizes",
+		},
+		"unknownLayerType": {
+			config:   `{"input": {"width": 4, "height": 4, "depth": 1}, "layers": [{"type": "fc"}, {"type": "unknown"}]}`,
+			expected: nnet.ErrorLayerTypeUnknown,
+			message:  "layer 1: unknown: unknown layer type",
+		},
+		"unknownOption": {
+			config: `{"input": {"width": 4, "height": 4, "depth": 1}, "layers": [
+				{"type": "conv", "options": {"filterSize": 3}},
+				{"type": "fc", "options": {"outputSize": [2, 1, 1]}}
+			]}`,
+			message: `layer 1: json: unknown field "outputSize"`,
+		},
+		"unknownFuncOption": {
+			config:  `{"input": {"width": 4, "height": 4, "depth": 1}, "layers": [{"type": "activation", "options": {"func": "relu", "k": 1}}]}`,
+			message: `layer 0: json: unknown field "k"`,
+		},
+		"unknownKey": {
+			config:  `{"input": {"width": 4, "height": 4, "depth": 1}, "layer": [{"type": "fc"}]}`,
+			message: `json: unknown field "layer"`,
+		},
+		"missingLayerType": {
+			config:   `{"input": {"width": 4, "height": 4, "depth": 1}, "layers": [{}]}`,
+			expected: ErrorLayerTypeMissing,
+			message:  "layer 0: layer type missing",
+		},
+	}
+
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			net, err := NewFromJSON(strings.NewReader(tc.config))
+			assert.Nil(t, net)
+			if tc.expected != nil {
+				assert.Equal(t, tc.expected, errors.Cause(err))
+			}
+			assert.EqualError(t, err, tc.message)
+		})
+	}
+}
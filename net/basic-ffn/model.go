@@ -0,0 +1,97 @@
+package basic_ffn
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/drdreyworld/nnet"
+	_ "github.com/drdreyworld/nnet/layer/activation"
+	_ "github.com/drdreyworld/nnet/layer/batchnorm"
+	_ "github.com/drdreyworld/nnet/layer/conv"
+	_ "github.com/drdreyworld/nnet/layer/deconv"
+	_ "github.com/drdreyworld/nnet/layer/dropout"
+	_ "github.com/drdreyworld/nnet/layer/fc"
+	_ "github.com/drdreyworld/nnet/layer/groupnorm"
+	_ "github.com/drdreyworld/nnet/layer/layernorm"
+	_ "github.com/drdreyworld/nnet/layer/pooling"
+	_ "github.com/drdreyworld/nnet/layer/softmax"
+	_ "github.com/drdreyworld/nnet/layer/upsampling"
+	"github.com/pkg/errors"
+)
+
+const ModelVersion = 1
+
+var ErrorModelVersion = errors.New("unsupported model version")
+
+// model is the on-disk representation of a network: input sizes and
+// every layer with its type name and exported options and parameters.
+type model struct {
+	Version int
+
+	IWidth, IHeight, IDepth int
+
+	Layers []modelLayer
+}
+
+type modelLayer struct {
+	Type  string
+	State json.RawMessage
+}
+
+func (n *ffnet) Save(w io.Writer) error {
+	m := model{
+		Version: ModelVersion,
+		IWidth:  n.iWidth,
+		IHeight: n.iHeight,
+		IDepth:  n.iDepth,
+		Layers:  make([]modelLayer, len(n.Layers)),
+	}
+
+	for i, layer := range n.Layers {
+		t, err := nnet.GetLayerType(layer)
+		if err != nil {
+			return errors.Wrap(err, fmt.Sprintf("layer %d", i))
+		}
+
+		state, err := json.Marshal(layer)
+		if err != nil {
+			return errors.Wrap(err, fmt.Sprintf("layer %d", i))
+		}
+
+		m.Layers[i] = modelLayer{Type: t, State: state}
+	}
+
+	return json.NewEncoder(w).Encode(m)
+}
+
+// Load replaces input sizes and layers of the network with the saved ones and initializes it.
+func (n *ffnet) Load(r io.Reader) error {
+	m := model{}
+	if err := json.NewDecoder(r).Decode(&m); err != nil {
+		return err
+	}
+
+	if m.Version != ModelVersion {
+		return errors.Wrap(ErrorModelVersion, fmt.Sprintf("%d", m.Version))
+	}
+
+	layers := make(Layers, len(m.Layers))
+	for i, ml := range m.Layers {
+		layer, err := nnet.NewLayer(ml.Type, nil)
+		if err != nil {
+			return errors.Wrap(err, fmt.Sprintf("layer %d", i))
+		}
+
+		if err := json.Unmarshal(ml.State, layer); err != nil {
+			return errors.Wrap(err, fmt.Sprintf("layer %d", i))
+		}
+
+		layers[i] = layer
+	}
+
+	n.iWidth, n.iHeight, n.iDepth = m.IWidth, m.IHeight, m.IDepth
+	n.Layers = layers
+
+	return n.Init()
+}
@@ -0,0 +1,89 @@
+package basic_ffn
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/drdreyworld/nnet/activation/elu"
+	"github.com/drdreyworld/nnet/activation/sigmoid"
+	"github.com/drdreyworld/nnet/data"
+	"github.com/drdreyworld/nnet/layer/activation"
+	"github.com/drdreyworld/nnet/layer/batchnorm"
+	"github.com/drdreyworld/nnet/layer/conv"
+	"github.com/drdreyworld/nnet/layer/fc"
+	"github.com/drdreyworld/nnet/layer/pooling"
+	"github.com/drdreyworld/nnet/layer/softmax"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFfnet_SaveLoad(t *testing.T) {
+	net := New(6, 6, 2, Layers{
+		conv.New(conv.FilterSize(3), conv.FiltersCount(3), conv.Padding(1)),
+		activation.New(elu.New(0.3)),
+		pooling.New(pooling.FilterSize(2), pooling.Stride(2)),
+		fc.New(fc.OutputSizes(4, 1, 1)),
+		activation.New(sigmoid.New()),
+		softmax.New(),
+	})
+	assert.NoError(t, net.Init())
+
+	inputs := &data.Data{}
+	inputs.InitCubeRandom(6, 6, 2, -1, 1)
+
+	expected := net.Activate(inputs).Copy()
+
+	saved := &bytes.Buffer{}
+	assert.NoError(t, net.Save(saved))
+
+	loaded := New(0, 0, 0, nil)
+	assert.NoError(t, loaded.Load(bytes.NewReader(saved.Bytes())))
+
+	assert.Equal(t, expected, loaded.Activate(inputs))
+
+	// check than options, params and activation funcs survive the round trip
+	resaved := &bytes.Buffer{}
+	assert.NoError(t, loaded.Save(resaved))
+	assert.Equal(t, saved.String(), resaved.String())
+	assert.Contains(t, saved.String(), `"Func":"elu","Params":{"K":0.3}`)
+}
+
+func TestFfnet_SaveLoadRunningStatistics(t *testing.T) {
+	net := New(2, 2, 2, Layers{
+		conv.New(conv.FilterSize(1), conv.FiltersCount(2)),
+		batchnorm.New(),
+		fc.New(fc.OutputSizes(3, 1, 1)),
+		batchnorm.New(batchnorm.PerFeature()),
+	})
+	assert.NoError(t, net.Init())
+
+	batch := &data.Data{}
+	batch.InitBatch(2, 2, 2, 4)
+	batch.FillRandom(-1, 1)
+	net.Activate(batch)
+
+	saved := &bytes.Buffer{}
+	assert.NoError(t, net.Save(saved))
+
+	loaded := New(0, 0, 0, nil)
+	assert.NoError(t, loaded.Load(bytes.NewReader(saved.Bytes())))
+
+	inputs := &data.Data{}
+	inputs.InitCubeRandom(2, 2, 2, -1, 1)
+
+	for _, n := range []*ffnet{net, loaded} {
+		for _, layer := range n.Layers {
+			if l, ok := layer.(interface{ Eval() }); ok {
+				l.Eval()
+			}
+		}
+	}
+
+	assert.Equal(t, net.Activate(inputs), loaded.Activate(inputs))
+}
+
+func TestFfnet_LoadUnsupportedVersion(t *testing.T) {
+	err := New(0, 0, 0, nil).Load(strings.NewReader(`{"Version":100}`))
+	assert.Equal(t, ErrorModelVersion, errors.Cause(err))
+}
@@ -1,10 +1,16 @@
 package basic_ffn
 
 import (
+	"fmt"
+	"sync"
+
 	"github.com/drdreyworld/nnet"
 	"github.com/drdreyworld/nnet/data"
+	"github.com/pkg/errors"
 )
 
+var ErrorInvalidDataSizes = errors.New("invalid data sizes")
+
 type Layers []nnet.Layer
 
 func New(iWidth, iHeight, iDepth int, layers Layers) *ffnet {
@@ -13,6 +19,8 @@ func New(iWidth, iHeight, iDepth int, layers Layers) *ffnet {
 		iHeight: iHeight,
 		iDepth:  iDepth,
 		Layers:  layers,
+
+		sessions: &sync.Pool{},
 	}
 }
 
@@ -21,15 +29,27 @@ type ffnet struct {
 	oWidth, oHeight, oDepth int
 
 	Layers Layers
+
+	// sessions holds clones of the net for concurrent Predict calls
+	sessions *sync.Pool
 }
 
 func (n *ffnet) Init() (err error) {
 	w, h, d := n.iWidth, n.iHeight, n.iDepth
+	if w < 1 || h < 1 || d < 1 {
+		return errors.Wrap(ErrorInvalidDataSizes, fmt.Sprintf("input: %dx%dx%d", w, h, d))
+	}
+
 	for i := 0; i < len(n.Layers); i++ {
 		w, h, d = n.Layers[i].InitDataSizes(w, h, d)
+
+		if w < 1 || h < 1 || d < 1 {
+			return errors.Wrap(ErrorInvalidDataSizes, fmt.Sprintf("layer %d output: %dx%dx%d", i, w, h, d))
+		}
 	}
 
 	n.oWidth, n.oHeight, n.oDepth = w, h, d
+	n.sessions = &sync.Pool{}
 	return
 }
 
@@ -59,3 +79,50 @@ func (n *ffnet) GetLayer(index int) nnet.Layer {
 	}
 	return nil
 }
+
+// Train switches layers implementing nnet.LayerWithMode to training mode.
+func (n *ffnet) Train() {
+	for _, layer := range n.Layers {
+		if l, ok := layer.(nnet.LayerWithMode); ok {
+			l.Train()
+		}
+	}
+}
+
+// Eval switches layers implementing nnet.LayerWithMode to inference mode, for example dropout passes inputs as is.
+func (n *ffnet) Eval() {
+	for _, layer := range n.Layers {
+		if l, ok := layer.(nnet.LayerWithMode); ok {
+			l.Eval()
+		}
+	}
+}
+
+// Clone returns initialized net sharing weights with this one, but with own
+// activation buffers, so the clone can be used in another goroutine.
+func (n *ffnet) Clone() (*ffnet, error) {
+	layers, err := nnet.CloneLayers(n.Layers)
+	if err != nil {
+		return nil, err
+	}
+
+	c := New(n.iWidth, n.iHeight, n.iDepth, layers)
+	c.oWidth, c.oHeight, c.oDepth = n.oWidth, n.oHeight, n.oDepth
+	return c, nil
+}
+
+// Predict activates the net in eval mode with inputs and returns a copy of the output.
+// It is safe for concurrent use, while weights are not changed by training.
+func (n *ffnet) Predict(inputs *data.Data) (*data.Data, error) {
+	session, ok := n.sessions.Get().(*ffnet)
+	if !ok {
+		var err error
+		if session, err = n.Clone(); err != nil {
+			return nil, err
+		}
+		session.Eval()
+	}
+	defer n.sessions.Put(session)
+
+	return session.Activate(inputs).Copy(), nil
+}
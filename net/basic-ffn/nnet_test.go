@@ -0,0 +1,115 @@
+package basic_ffn
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/drdreyworld/nnet"
+	"github.com/drdreyworld/nnet/activation/relu"
+	"github.com/drdreyworld/nnet/data"
+	"github.com/drdreyworld/nnet/layer/activation"
+	"github.com/drdreyworld/nnet/layer/conv"
+	"github.com/drdreyworld/nnet/layer/dropout"
+	"github.com/drdreyworld/nnet/layer/fc"
+	"github.com/drdreyworld/nnet/layer/softmax"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFfnet_Clone(t *testing.T) {
+	net := New(4, 4, 1, Layers{
+		conv.New(conv.FiltersCount(2)),
+		activation.New(relu.New()),
+		fc.New(fc.OutputSizes(3, 1, 1)),
+	})
+	assert.NoError(t, net.Init())
+
+	clone, err := net.Clone()
+	assert.NoError(t, err)
+
+	inputs := &data.Data{}
+	inputs.InitCubeRandom(4, 4, 1, -1, 1)
+
+	output := clone.Activate(inputs)
+	assert.Equal(t, net.Activate(inputs), output)
+	assert.False(t, net.Activate(inputs) == output, "output buffer is shared")
+
+	// check than weights are shared
+	w := net.Layers[2].(nnet.LayerWithWeights).GetWeights()
+	w.Data[0] += 1
+
+	assert.Equal(t, net.Activate(inputs), clone.Activate(inputs))
+}
+
+func TestFfnet_PredictConcurrently(t *testing.T) {
+	net := New(4, 4, 1, Layers{
+		conv.New(conv.FiltersCount(2)),
+		activation.New(relu.New()),
+		fc.New(fc.OutputSizes(3, 1, 1)),
+		softmax.New(),
+	})
+	assert.NoError(t, net.Init())
+
+	inputs := make([]*data.Data, 20)
+	expected := make([]*data.Data, len(inputs))
+	for i := range inputs {
+		inputs[i] = &data.Data{}
+		inputs[i].InitCubeRandom(4, 4, 1, -1, 1)
+		expected[i] = net.Activate(inputs[i]).Copy()
+	}
+
+	wg := sync.WaitGroup{}
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range inputs {
+				output, err := net.Predict(inputs[i])
+				assert.NoError(t, err)
+				assert.Equal(t, expected[i], output)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+type layerStub struct{}
+
+func (l *layerStub) InitDataSizes(w, h, d int) (int, int, int) { return w, h, d }
+func (l *layerStub) Activate(inputs *data.Data) *data.Data     { return inputs }
+func (l *layerStub) Backprop(deltas *data.Data) *data.Data     { return deltas }
+
+func TestFfnet_PredictNotCloneable(t *testing.T) {
+	net := New(1, 1, 1, Layers{&layerStub{}})
+	assert.NoError(t, net.Init())
+
+	output, err := net.Predict(data.NewVector(1))
+	assert.Nil(t, output)
+	assert.Equal(t, nnet.ErrorLayerNotCloneable, errors.Cause(err))
+}
+
+func TestFfnet_TrainEval(t *testing.T) {
+	net := New(4, 4, 2, Layers{
+		fc.New(fc.OutputSizes(8, 1, 1)),
+		dropout.New(dropout.Rate(0.5)),
+		fc.New(fc.OutputSizes(2, 1, 1)),
+	})
+	assert.NoError(t, net.Init())
+
+	inputs := &data.Data{}
+	inputs.InitCubeRandom(4, 4, 2, -1, 1)
+
+	first := net.Activate(inputs).Copy()
+	assert.NotEqual(t, first, net.Activate(inputs), "dropout is disabled in train mode")
+
+	net.Eval()
+	expected := net.Activate(inputs).Copy()
+	assert.Equal(t, expected, net.Activate(inputs))
+
+	net.Train()
+	assert.NotEqual(t, expected, net.Activate(inputs))
+
+	predicted, err := net.Predict(inputs)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, predicted, "predict does not use eval mode")
+}
@@ -0,0 +1,45 @@
+package adagrad
+
+import (
+	"github.com/drdreyworld/nnet/data"
+	"github.com/drdreyworld/nnet/optimizer"
+	"math"
+)
+
+// New creates AdaGrad optimizer, usual values are 0.01, 1e-8.
+func New(learning, epsilon float64) *adagrad {
+	return &adagrad{
+		learnRate: learning,
+		epsilon:   epsilon,
+	}
+}
+
+type adagrad struct {
+	learnRate float64
+	epsilon   float64
+
+	squares []*data.Data
+}
+
+func (o *adagrad) Step(params []optimizer.Param) {
+	if len(o.squares) != len(params) {
+		o.squares = optimizer.NewState(params)
+	}
+
+	for k, p := range params {
+		w, g, s := p.Value, p.Gradient, o.squares[k]
+
+		for j := 0; j < len(w.Data); j++ {
+			s.Data[j] += g.Data[j] * g.Data[j]
+			w.Data[j] -= o.learnRate * g.Data[j] / (math.Sqrt(s.Data[j]) + o.epsilon)
+		}
+	}
+}
+
+func (o *adagrad) GetLearningRate() float64 {
+	return o.learnRate
+}
+
+func (o *adagrad) SetLearningRate(rate float64) {
+	o.learnRate = rate
+}
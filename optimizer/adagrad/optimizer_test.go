@@ -0,0 +1,31 @@
+package adagrad
+
+import (
+	"github.com/drdreyworld/nnet/data"
+	"github.com/drdreyworld/nnet/optimizer"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestOptimizer_Step(t *testing.T) {
+	weights := data.NewVector(0.1, 0.2, 0.3)
+	weightsGradients := data.NewVector(0.1, 0.2, 0.3)
+
+	biases := data.NewVector(0.5, 0.5, 0.7)
+	biasesGradients := data.NewVector(0.3, 0.4, 0.5)
+
+	params := []optimizer.Param{
+		{Value: weights, Gradient: weightsGradients},
+		{Value: biases, Gradient: biasesGradients},
+	}
+
+	o := New(0.1, 1e-8)
+	o.Step(params)
+	o.Step(params)
+
+	assert.InDeltaSlice(t, []float64{-0.07071066311865613, 0.029289329381344903, 0.1292893268813451}, weights.Data, 1e-12)
+	assert.InDeltaSlice(t, []float64{0.32928932688134505, 0.3292893256313451, 0.5292893248813452}, biases.Data, 1e-12)
+
+	assert.EqualValues(t, data.NewVector(0.1, 0.2, 0.3), weightsGradients, "weight gradients changed")
+	assert.EqualValues(t, data.NewVector(0.3, 0.4, 0.5), biasesGradients, "biases gradients changed")
+}
@@ -0,0 +1,70 @@
+package adam
+
+import (
+	"github.com/drdreyworld/nnet/data"
+	"github.com/drdreyworld/nnet/optimizer"
+	"math"
+)
+
+// New creates Adam optimizer, usual values are 0.001, 0.9, 0.999, 1e-8.
+func New(learning, beta1, beta2, epsilon float64) *adam {
+	return NewW(learning, beta1, beta2, epsilon, 0)
+}
+
+// NewW creates AdamW optimizer with weight decay decoupled from the gradient moments.
+func NewW(learning, beta1, beta2, epsilon, weightDecay float64) *adam {
+	return &adam{
+		learnRate:   learning,
+		beta1:       beta1,
+		beta2:       beta2,
+		epsilon:     epsilon,
+		weightDecay: weightDecay,
+	}
+}
+
+type adam struct {
+	learnRate   float64
+	beta1       float64
+	beta2       float64
+	epsilon     float64
+	weightDecay float64
+
+	step    int
+	moments []*data.Data
+	squares []*data.Data
+}
+
+func (o *adam) Step(params []optimizer.Param) {
+	if len(o.moments) != len(params) {
+		o.moments = optimizer.NewState(params)
+		o.squares = optimizer.NewState(params)
+		o.step = 0
+	}
+
+	o.step++
+
+	correction1 := 1 - math.Pow(o.beta1, float64(o.step))
+	correction2 := 1 - math.Pow(o.beta2, float64(o.step))
+
+	for k, p := range params {
+		w, g, m, v := p.Value, p.Gradient, o.moments[k], o.squares[k]
+
+		for j := 0; j < len(w.Data); j++ {
+			m.Data[j] = o.beta1*m.Data[j] + (1-o.beta1)*g.Data[j]
+			v.Data[j] = o.beta2*v.Data[j] + (1-o.beta2)*g.Data[j]*g.Data[j]
+
+			mHat := m.Data[j] / correction1
+			vHat := v.Data[j] / correction2
+
+			w.Data[j] -= o.learnRate * (mHat/(math.Sqrt(vHat)+o.epsilon) + o.weightDecay*w.Data[j])
+		}
+	}
+}
+
+func (o *adam) GetLearningRate() float64 {
+	return o.learnRate
+}
+
+func (o *adam) SetLearningRate(rate float64) {
+	o.learnRate = rate
+}
@@ -0,0 +1,40 @@
+package adam
+
+import (
+	"github.com/drdreyworld/nnet/data"
+	"github.com/drdreyworld/nnet/optimizer"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestOptimizer_Step(t *testing.T) {
+	weights := data.NewVector(0.1, 0.2, 0.3)
+	weightsGradients := data.NewVector(0.1, 0.2, 0.3)
+
+	biases := data.NewVector(0.5, 0.5, 0.7)
+	biasesGradients := data.NewVector(0.3, 0.4, 0.5)
+
+	params := []optimizer.Param{
+		{Value: weights, Gradient: weightsGradients},
+		{Value: biases, Gradient: biasesGradients},
+	}
+
+	o := New(0.01, 0.9, 0.999, 1e-8)
+	o.Step(params)
+	o.Step(params)
+
+	assert.InDeltaSlice(t, []float64{0.08000000199999988, 0.18000000100000005, 0.2800000006666667}, weights.Data, 1e-12)
+	assert.InDeltaSlice(t, []float64{0.4800000006666667, 0.4800000005000001, 0.6800000004000001}, biases.Data, 1e-12)
+
+	assert.EqualValues(t, data.NewVector(0.1, 0.2, 0.3), weightsGradients, "weight gradients changed")
+	assert.EqualValues(t, data.NewVector(0.3, 0.4, 0.5), biasesGradients, "biases gradients changed")
+}
+
+func TestNewW(t *testing.T) {
+	weights := data.NewVector(0.1, 0.2, 0.3)
+	gradients := data.NewVector(0.1, 0.2, 0.3)
+
+	NewW(0.01, 0.9, 0.999, 1e-8, 0.1).Step([]optimizer.Param{{Value: weights, Gradient: gradients}})
+
+	assert.InDeltaSlice(t, []float64{0.08990000099999991, 0.18980000049999998, 0.2897000003333333}, weights.Data, 1e-12)
+}
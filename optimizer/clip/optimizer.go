@@ -0,0 +1,81 @@
+package clip
+
+import (
+	"math"
+
+	"github.com/drdreyworld/nnet/optimizer"
+)
+
+// New creates optimizer which clips gradients in place before the step of the wrapped optimizer.
+// Gradients are scaled down when their global L2 norm is greater than norm and then every
+// gradient is limited by [-value, value], zero value or norm disables the clipping.
+func New(optimizer optimizer.Optimizer, value, norm float64) *clip {
+	return &clip{
+		optimizer: optimizer,
+		value:     value,
+		norm:      norm,
+	}
+}
+
+type clip struct {
+	optimizer optimizer.Optimizer
+
+	value float64
+	norm  float64
+
+	lastNorm float64
+}
+
+func (o *clip) Step(params []optimizer.Param) {
+	o.lastNorm = Norm(params)
+
+	if o.norm > 0 && o.lastNorm > o.norm {
+		scale := o.norm / o.lastNorm
+		for _, p := range params {
+			for j := range p.Gradient.Data {
+				p.Gradient.Data[j] *= scale
+			}
+		}
+	}
+
+	if o.value > 0 {
+		for _, p := range params {
+			for j, g := range p.Gradient.Data {
+				p.Gradient.Data[j] = math.Max(-o.value, math.Min(o.value, g))
+			}
+		}
+	}
+
+	o.optimizer.Step(params)
+}
+
+// GetLearningRate returns learning rate of the wrapped optimizer, zero for optimizers without it.
+func (o *clip) GetLearningRate() float64 {
+	if r, ok := o.optimizer.(optimizer.OptimizerWithLearningRate); ok {
+		return r.GetLearningRate()
+	}
+	return 0
+}
+
+// SetLearningRate sets learning rate of the wrapped optimizer, so clipped optimizers can be scheduled.
+func (o *clip) SetLearningRate(rate float64) {
+	if r, ok := o.optimizer.(optimizer.OptimizerWithLearningRate); ok {
+		r.SetLearningRate(rate)
+	}
+}
+
+// GetNorm returns global norm of the gradients of the last step before clipping.
+func (o *clip) GetNorm() float64 {
+	return o.lastNorm
+}
+
+// Norm returns global L2 norm of the params gradients.
+func Norm(params []optimizer.Param) float64 {
+	sum := 0.0
+	for _, p := range params {
+		for _, g := range p.Gradient.Data {
+			sum += g * g
+		}
+	}
+	return math.Sqrt(sum)
+}
@@ -0,0 +1,74 @@
+package clip
+
+import (
+	"testing"
+
+	"github.com/drdreyworld/nnet/data"
+	"github.com/drdreyworld/nnet/optimizer"
+	"github.com/drdreyworld/nnet/optimizer/sgd"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOptimizer_Step(t *testing.T) {
+	type testCase struct {
+		value, norm     float64
+		expectedWeights []float64
+		expectedBiases  []float64
+	}
+	testCases := map[string]testCase{
+		"None": {
+			expectedWeights: []float64{-3, 4},
+			expectedBiases:  []float64{0},
+		},
+		"Value": {
+			value:           2,
+			expectedWeights: []float64{-2, 2},
+			expectedBiases:  []float64{0},
+		},
+		"Norm": {
+			norm:            1,
+			expectedWeights: []float64{-0.6000000000000001, 0.8},
+			expectedBiases:  []float64{0},
+		},
+		"NormNotExceeded": {
+			norm:            10,
+			expectedWeights: []float64{-3, 4},
+			expectedBiases:  []float64{0},
+		},
+		"NormAndValue": {
+			value:           0.7,
+			norm:            1,
+			expectedWeights: []float64{-0.6000000000000001, 0.7},
+			expectedBiases:  []float64{0},
+		},
+	}
+
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			weights := data.NewVector(0, 0)
+			biases := data.NewVector(0)
+
+			params := []optimizer.Param{
+				{Value: weights, Gradient: data.NewVector(3, -4)},
+				{Value: biases, Gradient: data.NewVector(0)},
+			}
+
+			o := New(sgd.New(1, 0, 0), tc.value, tc.norm)
+			o.Step(params)
+
+			assert.Equal(t, tc.expectedWeights, weights.Data)
+			assert.Equal(t, tc.expectedBiases, biases.Data)
+			assert.Equal(t, 5.0, o.GetNorm())
+		})
+	}
+}
+
+func TestOptimizer_LearningRate(t *testing.T) {
+	o := sgd.New(0.1, 0, 0)
+	c := New(o, 0, 1)
+	assert.Equal(t, 0.1, c.GetLearningRate())
+
+	c.SetLearningRate(0.3)
+	assert.Equal(t, 0.3, o.GetLearningRate())
+}
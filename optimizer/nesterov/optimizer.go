@@ -0,0 +1,48 @@
+package nesterov
+
+import (
+	"github.com/drdreyworld/nnet/data"
+	"github.com/drdreyworld/nnet/optimizer"
+)
+
+// New creates SGD optimizer with Nesterov momentum, weight decay is added to the gradient.
+func New(learning, momentum, weightDecay float64) *nesterov {
+	return &nesterov{
+		learnRate:   learning,
+		momentum:    momentum,
+		weightDecay: weightDecay,
+	}
+}
+
+type nesterov struct {
+	learnRate   float64
+	momentum    float64
+	weightDecay float64
+
+	velocities []*data.Data
+}
+
+func (o *nesterov) Step(params []optimizer.Param) {
+	if len(o.velocities) != len(params) {
+		o.velocities = optimizer.NewState(params)
+	}
+
+	for k, p := range params {
+		w, g, v := p.Value, p.Gradient, o.velocities[k]
+
+		for j := 0; j < len(w.Data); j++ {
+			grad := g.Data[j] + o.weightDecay*w.Data[j]
+
+			v.Data[j] = o.momentum*v.Data[j] + grad
+			w.Data[j] -= o.learnRate * (grad + o.momentum*v.Data[j])
+		}
+	}
+}
+
+func (o *nesterov) GetLearningRate() float64 {
+	return o.learnRate
+}
+
+func (o *nesterov) SetLearningRate(rate float64) {
+	o.learnRate = rate
+}
@@ -0,0 +1,31 @@
+package nesterov
+
+import (
+	"github.com/drdreyworld/nnet/data"
+	"github.com/drdreyworld/nnet/optimizer"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestOptimizer_Step(t *testing.T) {
+	weights := data.NewVector(0.1, 0.2, 0.3)
+	weightsGradients := data.NewVector(0.1, 0.2, 0.3)
+
+	biases := data.NewVector(0.5, 0.5, 0.7)
+	biasesGradients := data.NewVector(0.3, 0.4, 0.5)
+
+	params := []optimizer.Param{
+		{Value: weights, Gradient: weightsGradients},
+		{Value: biases, Gradient: biasesGradients},
+	}
+
+	o := New(0.1, 0.9, 0.01)
+	o.Step(params)
+	o.Step(params)
+
+	assert.InDeltaSlice(t, []float64{0.053475461, 0.106950922, 0.16042638299999998}, weights.Data, 1e-12)
+	assert.InDeltaSlice(t, []float64{0.359505105, 0.31344120499999995, 0.4664560269999999}, biases.Data, 1e-12)
+
+	assert.EqualValues(t, data.NewVector(0.1, 0.2, 0.3), weightsGradients, "weight gradients changed")
+	assert.EqualValues(t, data.NewVector(0.3, 0.4, 0.5), biasesGradients, "biases gradients changed")
+}
@@ -0,0 +1,33 @@
+package optimizer
+
+import (
+	"github.com/drdreyworld/nnet/data"
+)
+
+// Param is a trainable parameter with the gradient of the loss by it.
+type Param struct {
+	Value    *data.Data
+	Gradient *data.Data
+}
+
+// Optimizer updates params by their gradients. Params are passed in the same
+// order on every step, so optimizers keep per-param state by index.
+type Optimizer interface {
+	Step(params []Param)
+}
+
+// NewState returns zero buffers with the shapes of params gradients.
+func NewState(params []Param) []*data.Data {
+	state := make([]*data.Data, len(params))
+	for i, p := range params {
+		state[i] = p.Gradient.CopyZero()
+	}
+	return state
+}
+
+// OptimizerWithLearningRate allows schedules to change learning rate between steps.
+type OptimizerWithLearningRate interface {
+	Optimizer
+	GetLearningRate() float64
+	SetLearningRate(rate float64)
+}
@@ -0,0 +1,16 @@
+package optimizer
+
+import (
+	"github.com/drdreyworld/nnet/data"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestNewState(t *testing.T) {
+	params := []Param{
+		{Value: data.NewVector(1, 2), Gradient: data.NewVector(3, 4)},
+		{Value: data.NewVector(5), Gradient: data.NewVector(6)},
+	}
+
+	assert.Equal(t, []*data.Data{data.NewVector(0, 0), data.NewVector(0)}, NewState(params))
+}
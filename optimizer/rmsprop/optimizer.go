@@ -0,0 +1,47 @@
+package rmsprop
+
+import (
+	"github.com/drdreyworld/nnet/data"
+	"github.com/drdreyworld/nnet/optimizer"
+	"math"
+)
+
+// New creates RMSProp optimizer, usual values are 0.001, 0.9, 1e-8.
+func New(learning, rho, epsilon float64) *rmsprop {
+	return &rmsprop{
+		learnRate: learning,
+		rho:       rho,
+		epsilon:   epsilon,
+	}
+}
+
+type rmsprop struct {
+	learnRate float64
+	rho       float64
+	epsilon   float64
+
+	squares []*data.Data
+}
+
+func (o *rmsprop) Step(params []optimizer.Param) {
+	if len(o.squares) != len(params) {
+		o.squares = optimizer.NewState(params)
+	}
+
+	for k, p := range params {
+		w, g, s := p.Value, p.Gradient, o.squares[k]
+
+		for j := 0; j < len(w.Data); j++ {
+			s.Data[j] = o.rho*s.Data[j] + (1-o.rho)*g.Data[j]*g.Data[j]
+			w.Data[j] -= o.learnRate * g.Data[j] / (math.Sqrt(s.Data[j]) + o.epsilon)
+		}
+	}
+}
+
+func (o *rmsprop) GetLearningRate() float64 {
+	return o.learnRate
+}
+
+func (o *rmsprop) SetLearningRate(rate float64) {
+	o.learnRate = rate
+}
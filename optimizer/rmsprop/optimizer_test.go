@@ -0,0 +1,31 @@
+package rmsprop
+
+import (
+	"github.com/drdreyworld/nnet/data"
+	"github.com/drdreyworld/nnet/optimizer"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestOptimizer_Step(t *testing.T) {
+	weights := data.NewVector(0.1, 0.2, 0.3)
+	weightsGradients := data.NewVector(0.1, 0.2, 0.3)
+
+	biases := data.NewVector(0.5, 0.5, 0.7)
+	biasesGradients := data.NewVector(0.3, 0.4, 0.5)
+
+	params := []optimizer.Param{
+		{Value: weights, Gradient: weightsGradients},
+		{Value: biases, Gradient: biasesGradients},
+	}
+
+	o := New(0.01, 0.9, 1e-8)
+	o.Step(params)
+	o.Step(params)
+
+	assert.InDeltaSlice(t, []float64{0.04543566527441357, 0.1454356576428379, 0.2454356550989788}, weights.Data, 1e-12)
+	assert.InDeltaSlice(t, []float64{0.4454356550989788, 0.4454356538270492, 0.6454356530638914}, biases.Data, 1e-12)
+
+	assert.EqualValues(t, data.NewVector(0.1, 0.2, 0.3), weightsGradients, "weight gradients changed")
+	assert.EqualValues(t, data.NewVector(0.3, 0.4, 0.5), biasesGradients, "biases gradients changed")
+}
@@ -0,0 +1,48 @@
+package sgd
+
+import (
+	"github.com/drdreyworld/nnet/data"
+	"github.com/drdreyworld/nnet/optimizer"
+)
+
+// New creates SGD optimizer, momentum and weight decay can be zero.
+func New(learning, momentum, weightDecay float64) *sgd {
+	return &sgd{
+		learnRate:   learning,
+		momentum:    momentum,
+		weightDecay: weightDecay,
+	}
+}
+
+type sgd struct {
+	learnRate   float64
+	momentum    float64
+	weightDecay float64
+
+	velocities []*data.Data
+}
+
+func (o *sgd) Step(params []optimizer.Param) {
+	if len(o.velocities) != len(params) {
+		o.velocities = optimizer.NewState(params)
+	}
+
+	for k, p := range params {
+		w, g, v := p.Value, p.Gradient, o.velocities[k]
+
+		for j := 0; j < len(w.Data); j++ {
+			value := v.Data[j]*o.momentum + o.learnRate*g.Data[j] + o.weightDecay*w.Data[j]
+
+			w.Data[j] -= value
+			v.Data[j] = value
+		}
+	}
+}
+
+func (o *sgd) GetLearningRate() float64 {
+	return o.learnRate
+}
+
+func (o *sgd) SetLearningRate(rate float64) {
+	o.learnRate = rate
+}
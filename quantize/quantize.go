@@ -0,0 +1,97 @@
+// Package quantize converts layers of a trained net to int8 or float32 layers for inference.
+package quantize
+
+import (
+	"math"
+
+	"github.com/drdreyworld/nnet"
+	"github.com/drdreyworld/nnet/data"
+	"github.com/pkg/errors"
+)
+
+var ErrorNoSamples = errors.New("no calibration samples")
+
+type Net interface {
+	GetLayersCount() int
+	GetLayer(index int) nnet.Layer
+}
+
+// Layer is implemented by layers having int8 variant, like fc and conv.
+type Layer interface {
+	nnet.Layer
+	Quantize(inputScale float64) nnet.Layer
+}
+
+// Calibrate activates layers of the net by samples one by one and returns
+// max absolute value of inputs of every layer.
+func Calibrate(net Net, samples []*data.Data) ([]float64, error) {
+	if len(samples) == 0 {
+		return nil, ErrorNoSamples
+	}
+
+	res := make([]float64, net.GetLayersCount())
+	for _, inputs := range samples {
+		for i := range res {
+			res[i] = math.Max(res[i], inputs.GetMaxAbsValue(0, len(inputs.Data)))
+			inputs = net.GetLayer(i).Activate(inputs)
+		}
+	}
+	return res, nil
+}
+
+// Layers returns layers of the net with layers implementing Layer replaced by int8 variants,
+// scales of their inputs are calibrated by samples. Other layers are shared with the net.
+// Net should be in eval mode, result should be initialized by a new net.
+func Layers(net Net, samples []*data.Data) ([]nnet.Layer, error) {
+	ranges, err := Calibrate(net, samples)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]nnet.Layer, net.GetLayersCount())
+	for i := range res {
+		res[i] = net.GetLayer(i)
+
+		if layer, ok := res[i].(Layer); ok {
+			res[i] = layer.Quantize(data.Int8Scale(ranges[i]))
+		}
+	}
+	return res, nil
+}
+
+// Float32Layer is implemented by layers with parameters: fc, conv, deconv, batchnorm, layernorm and groupnorm.
+type Float32Layer interface {
+	nnet.Layer
+	Float32() nnet.Layer
+}
+
+// Float32Layers returns layers of the net with layers implementing Float32Layer replaced
+// by float32 variants, other layers have no parameters and are shared with the net.
+// Net should be in eval mode, result should be initialized by a new net.
+func Float32Layers(net Net) []nnet.Layer {
+	res := make([]nnet.Layer, net.GetLayersCount())
+	for i := range res {
+		res[i] = net.GetLayer(i)
+		if layer, ok := res[i].(Float32Layer); ok {
+			res[i] = layer.Float32()
+		}
+	}
+	return res
+}
+
+type Activator interface {
+	Activate(inputs *data.Data) (output *data.Data)
+}
+
+// MaxError returns max absolute difference between outputs of the nets on samples.
+func MaxError(a, b Activator, samples []*data.Data) float64 {
+	res := 0.0
+	for _, inputs := range samples {
+		x, y := a.Activate(inputs), b.Activate(inputs)
+
+		for i := range x.Data {
+			res = math.Max(res, math.Abs(x.Data[i]-y.Data[i]))
+		}
+	}
+	return res
+}
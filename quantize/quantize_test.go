@@ -0,0 +1,114 @@
+package quantize
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/drdreyworld/nnet/activation/relu"
+	"github.com/drdreyworld/nnet/data"
+	"github.com/drdreyworld/nnet/gradcheck"
+	"github.com/drdreyworld/nnet/layer/activation"
+	"github.com/drdreyworld/nnet/layer/batchnorm"
+	"github.com/drdreyworld/nnet/layer/conv"
+	"github.com/drdreyworld/nnet/layer/deconv"
+	"github.com/drdreyworld/nnet/layer/fc"
+	"github.com/drdreyworld/nnet/layer/groupnorm"
+	"github.com/drdreyworld/nnet/layer/layernorm"
+	"github.com/drdreyworld/nnet/layer/pooling"
+	ffnet "github.com/drdreyworld/nnet/net/basic-ffn"
+	"github.com/stretchr/testify/assert"
+)
+
+func samples(count int) []*data.Data {
+	res := make([]*data.Data, count)
+	for i := range res {
+		res[i] = gradcheck.Inputs(8, 8, 1, 1, int64(i+1))
+	}
+	return res
+}
+
+func TestLayers(t *testing.T) {
+	net := ffnet.New(8, 8, 1, ffnet.Layers{
+		conv.New(conv.FilterSize(3), conv.FiltersCount(4), conv.Padding(1)),
+		activation.New(relu.New()),
+		pooling.New(pooling.FilterSize(2), pooling.Stride(2)),
+		fc.New(fc.OutputSizes(3, 1, 1)),
+	})
+	assert.NoError(t, net.Init())
+
+	layers, err := Layers(net, samples(10))
+	assert.NoError(t, err)
+	assert.Len(t, layers, 4)
+
+	assert.NotEqual(t, net.GetLayer(0), layers[0])
+	assert.Equal(t, net.GetLayer(1), layers[1])
+	assert.Equal(t, net.GetLayer(2), layers[2])
+	assert.NotEqual(t, net.GetLayer(3), layers[3])
+
+	quantized := ffnet.New(8, 8, 1, layers)
+	assert.NoError(t, quantized.Init())
+
+	assert.Less(t, MaxError(net, quantized, samples(5)), 0.05)
+
+	buffer := &bytes.Buffer{}
+	assert.NoError(t, quantized.Save(buffer))
+
+	restored := ffnet.New(0, 0, 0, nil)
+	assert.NoError(t, restored.Load(buffer))
+	assert.Equal(t, 0.0, MaxError(quantized, restored, samples(5)))
+}
+
+func TestFloat32Layers(t *testing.T) {
+	net := ffnet.New(8, 8, 1, ffnet.Layers{
+		conv.New(conv.FilterSize(3), conv.FiltersCount(4), conv.Padding(1)),
+		batchnorm.New(),
+		activation.New(relu.New()),
+		deconv.New(deconv.FilterSize(2), deconv.FiltersCount(4), deconv.Stride(2)),
+		groupnorm.New(groupnorm.Groups(2)),
+		layernorm.New(),
+		fc.New(fc.OutputSizes(3, 1, 1)),
+	})
+	assert.NoError(t, net.Init())
+
+	for _, inputs := range samples(3) {
+		net.Activate(inputs)
+	}
+	net.Eval()
+
+	layers := Float32Layers(net)
+
+	assert.Len(t, layers, 7)
+	for i, layer := range layers {
+		if i == 2 {
+			assert.Equal(t, net.GetLayer(i), layer, "layers without parameters are shared")
+		} else {
+			assert.NotEqual(t, net.GetLayer(i), layer, "layer %d", i)
+		}
+	}
+
+	single := ffnet.New(8, 8, 1, layers)
+	assert.NoError(t, single.Init())
+	assert.Less(t, MaxError(net, single, samples(5)), 1e-5)
+
+	buffer := &bytes.Buffer{}
+	assert.NoError(t, single.Save(buffer))
+
+	restored := ffnet.New(0, 0, 0, nil)
+	assert.NoError(t, restored.Load(buffer))
+	assert.Equal(t, 0.0, MaxError(single, restored, samples(5)))
+}
+
+func TestCalibrate(t *testing.T) {
+	net := ffnet.New(2, 1, 1, ffnet.Layers{
+		activation.New(relu.New()),
+		fc.New(fc.OutputSizes(1, 1, 1)),
+	})
+	assert.NoError(t, net.Init())
+
+	ranges, err := Calibrate(net, []*data.Data{data.NewVector(0.5, -2), data.NewVector(1, 0.2)})
+	assert.NoError(t, err)
+	assert.Equal(t, []float64{2, 1}, ranges)
+
+	_, err = Calibrate(net, nil)
+	assert.Equal(t, ErrorNoSamples, err)
+}
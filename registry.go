@@ -0,0 +1,69 @@
+package nnet
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	ErrorLayerTypeUnknown       = errors.New("unknown layer type")
+	ErrorLayerTypeNotRegistered = errors.New("layer type not registered")
+)
+
+// LayerOptions holds options of a single layer from a network definition,
+// Decode returns error for options unknown to v.
+type LayerOptions interface {
+	Decode(v interface{}) error
+}
+
+// LayerConstructor creates a layer from options, nil options mean defaults.
+type LayerConstructor func(options LayerOptions) (Layer, error)
+
+var registry = struct {
+	sync.RWMutex
+	constructors map[string]LayerConstructor
+	names        map[reflect.Type]string
+}{
+	constructors: map[string]LayerConstructor{},
+	names:        map[reflect.Type]string{},
+}
+
+// RegisterLayer makes a layer type available by name for model loading and network definitions.
+// Layer packages call it from init.
+func RegisterLayer(name string, constructor LayerConstructor) {
+	layer, err := constructor(nil)
+	if err != nil {
+		panic(errors.Wrap(err, name))
+	}
+
+	registry.Lock()
+	defer registry.Unlock()
+
+	registry.constructors[name] = constructor
+	registry.names[reflect.TypeOf(layer)] = name
+}
+
+func NewLayer(name string, options LayerOptions) (Layer, error) {
+	registry.RLock()
+	constructor, ok := registry.constructors[name]
+	registry.RUnlock()
+
+	if !ok {
+		return nil, errors.Wrap(ErrorLayerTypeUnknown, name)
+	}
+	return constructor(options)
+}
+
+func GetLayerType(layer Layer) (string, error) {
+	registry.RLock()
+	defer registry.RUnlock()
+
+	name, ok := registry.names[reflect.TypeOf(layer)]
+	if !ok {
+		return "", errors.Wrap(ErrorLayerTypeNotRegistered, fmt.Sprintf("%T", layer))
+	}
+	return name, nil
+}
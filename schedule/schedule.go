@@ -0,0 +1,146 @@
+package schedule
+
+import (
+	"math"
+
+	"github.com/drdreyworld/nnet/optimizer"
+)
+
+// Schedule returns learning rate for the optimizer step, steps are counted from zero.
+type Schedule interface {
+	Rate(step int) float64
+}
+
+// Func is a stateless schedule.
+type Func func(step int) float64
+
+func (f Func) Rate(step int) float64 {
+	return f(step)
+}
+
+func Constant(rate float64) Func {
+	return func(step int) float64 {
+		return rate
+	}
+}
+
+// StepDecay multiplies initial rate by gamma every size steps.
+func StepDecay(initial, gamma float64, size int) Func {
+	if size < 1 {
+		size = 1
+	}
+	return func(step int) float64 {
+		return initial * math.Pow(gamma, float64(step/size))
+	}
+}
+
+// Exponential multiplies initial rate by gamma every step.
+func Exponential(initial, gamma float64) Func {
+	return func(step int) float64 {
+		return initial * math.Pow(gamma, float64(step))
+	}
+}
+
+// CosineRestarts anneals rate from max to min by cosine during period steps and then
+// restarts from max, every next period is mult times longer than the previous one.
+func CosineRestarts(max, min float64, period, mult int) Func {
+	if period < 1 {
+		period = 1
+	}
+	if mult < 1 {
+		mult = 1
+	}
+	return func(step int) float64 {
+		t, p := step, period
+		for t >= p {
+			t -= p
+			p *= mult
+		}
+		return min + (max-min)*(1+math.Cos(math.Pi*float64(t)/float64(p)))/2
+	}
+}
+
+// Warmup grows rate linearly to the first rate of the schedule during steps
+// and then continues with the schedule from its first step.
+func Warmup(steps int, schedule Schedule) Func {
+	return func(step int) float64 {
+		if step < steps {
+			return schedule.Rate(0) * float64(step+1) / float64(steps)
+		}
+		return schedule.Rate(step - steps)
+	}
+}
+
+// Observer is implemented by schedules depending on a metric of the training, like reduce on plateau.
+type Observer interface {
+	Observe(metric float64)
+}
+
+// NewReduceOnPlateau creates schedule which multiplies rate by factor when observed
+// metric, lower is better, did not improve by more than minDelta during patience observations.
+func NewReduceOnPlateau(initial, factor float64, patience int, minDelta, minRate float64) *reduceOnPlateau {
+	return &reduceOnPlateau{
+		rate:     initial,
+		factor:   factor,
+		patience: patience,
+		minDelta: minDelta,
+		minRate:  minRate,
+		best:     math.Inf(1),
+	}
+}
+
+type reduceOnPlateau struct {
+	rate     float64
+	factor   float64
+	patience int
+	minDelta float64
+	minRate  float64
+
+	best float64
+	wait int
+}
+
+func (s *reduceOnPlateau) Rate(step int) float64 {
+	return s.rate
+}
+
+// Observe registers metric, usually validation loss at the end of epoch.
+func (s *reduceOnPlateau) Observe(metric float64) {
+	if metric < s.best-s.minDelta {
+		s.best = metric
+		s.wait = 0
+		return
+	}
+
+	s.wait++
+	if s.wait > s.patience {
+		s.rate = math.Max(s.rate*s.factor, s.minRate)
+		s.wait = 0
+	}
+}
+
+// NewOptimizer sets learning rate of the optimizer by the schedule before every step.
+func NewOptimizer(optimizer optimizer.OptimizerWithLearningRate, schedule Schedule) *scheduled {
+	return &scheduled{
+		optimizer: optimizer,
+		schedule:  schedule,
+	}
+}
+
+type scheduled struct {
+	optimizer optimizer.OptimizerWithLearningRate
+	schedule  Schedule
+
+	step int
+}
+
+func (o *scheduled) Step(params []optimizer.Param) {
+	o.optimizer.SetLearningRate(o.schedule.Rate(o.step))
+	o.optimizer.Step(params)
+	o.step++
+}
+
+// GetStep returns count of the performed steps.
+func (o *scheduled) GetStep() int {
+	return o.step
+}
@@ -0,0 +1,86 @@
+package schedule
+
+import (
+	"testing"
+
+	"github.com/drdreyworld/nnet/data"
+	"github.com/drdreyworld/nnet/optimizer"
+	"github.com/drdreyworld/nnet/optimizer/sgd"
+	"github.com/stretchr/testify/assert"
+)
+
+func rates(s Schedule, steps int) []float64 {
+	res := make([]float64, steps)
+	for i := range res {
+		res[i] = s.Rate(i)
+	}
+	return res
+}
+
+func TestSchedules(t *testing.T) {
+	type testCase struct {
+		schedule Schedule
+		expected []float64
+	}
+	testCases := map[string]testCase{
+		"Constant": {
+			schedule: Constant(0.1),
+			expected: []float64{0.1, 0.1, 0.1},
+		},
+		"StepDecay": {
+			schedule: StepDecay(0.8, 0.5, 2),
+			expected: []float64{0.8, 0.8, 0.4, 0.4, 0.2},
+		},
+		"Exponential": {
+			schedule: Exponential(0.8, 0.5),
+			expected: []float64{0.8, 0.4, 0.2, 0.1},
+		},
+		"CosineRestarts": {
+			schedule: CosineRestarts(1, 0, 2, 2),
+			expected: []float64{1, 0.5, 1, 0.8535533905932737, 0.5, 0.14644660940672627, 1},
+		},
+		"Warmup": {
+			schedule: Warmup(4, Exponential(0.8, 0.5)),
+			expected: []float64{0.2, 0.4, 0.6000000000000001, 0.8, 0.8, 0.4},
+		},
+	}
+
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			assert.InDeltaSlice(t, tc.expected, rates(tc.schedule, len(tc.expected)), 1e-12)
+		})
+	}
+}
+
+func TestReduceOnPlateau(t *testing.T) {
+	s := NewReduceOnPlateau(1, 0.5, 1, 0.01, 0.3)
+
+	metrics := []float64{1, 0.9, 0.895, 0.95, 0.8, 0.85, 0.85, 0.85, 0.85, 0.85}
+	expected := []float64{1, 1, 1, 0.5, 0.5, 0.5, 0.3, 0.3, 0.3, 0.3}
+
+	actual := make([]float64, len(metrics))
+	for i, m := range metrics {
+		s.Observe(m)
+		actual[i] = s.Rate(i)
+	}
+
+	assert.Equal(t, expected, actual)
+}
+
+func TestOptimizer_Step(t *testing.T) {
+	weights := data.NewVector(1)
+	params := []optimizer.Param{{Value: weights, Gradient: data.NewVector(1)}}
+
+	o := sgd.New(0, 0, 0)
+	s := NewOptimizer(o, Exponential(0.5, 0.5))
+
+	s.Step(params)
+	assert.Equal(t, []float64{0.5}, weights.Data)
+
+	s.Step(params)
+	assert.Equal(t, []float64{0.25}, weights.Data)
+
+	assert.Equal(t, 2, s.GetStep())
+	assert.Equal(t, 0.25, o.GetLearningRate())
+}
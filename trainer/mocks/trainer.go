@@ -223,3 +223,52 @@ func (mr *MockTrainableLayerMockRecorder) GetBiasesWithGradient() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBiasesWithGradient", reflect.TypeOf((*MockTrainableLayer)(nil).GetBiasesWithGradient))
 }
+
+// MockTrainer is a mock of Trainer interface
+type MockTrainer struct {
+	ctrl     *gomock.Controller
+	recorder *MockTrainerMockRecorder
+}
+
+// MockTrainerMockRecorder is the mock recorder for MockTrainer
+type MockTrainerMockRecorder struct {
+	mock *MockTrainer
+}
+
+// NewMockTrainer creates a new mock instance
+func NewMockTrainer(ctrl *gomock.Controller) *MockTrainer {
+	mock := &MockTrainer{ctrl: ctrl}
+	mock.recorder = &MockTrainerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockTrainer) EXPECT() *MockTrainerMockRecorder {
+	return m.recorder
+}
+
+// Activate mocks base method
+func (m *MockTrainer) Activate(inputs, target *data.Data) *data.Data {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Activate", inputs, target)
+	ret0, _ := ret[0].(*data.Data)
+	return ret0
+}
+
+// Activate indicates an expected call of Activate
+func (mr *MockTrainerMockRecorder) Activate(inputs, target interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Activate", reflect.TypeOf((*MockTrainer)(nil).Activate), inputs, target)
+}
+
+// UpdateWeights mocks base method
+func (m *MockTrainer) UpdateWeights() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "UpdateWeights")
+}
+
+// UpdateWeights indicates an expected call of UpdateWeights
+func (mr *MockTrainerMockRecorder) UpdateWeights() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWeights", reflect.TypeOf((*MockTrainer)(nil).UpdateWeights))
+}
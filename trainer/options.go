@@ -0,0 +1,31 @@
+package trainer
+
+import (
+	"github.com/drdreyworld/nnet/schedule"
+)
+
+type Option func(t *Generic)
+
+// BatchSize makes trainer accumulate gradients of size activations before every weights update.
+func BatchSize(size int) Option {
+	return func(t *Generic) {
+		t.batchSize = size
+	}
+}
+
+// Schedule changes learning rate of the optimizer before every weights update,
+// it is ignored for optimizers without optimizer.OptimizerWithLearningRate.
+// Schedules implementing schedule.Observer get metrics passed to Observe.
+func Schedule(s schedule.Schedule) Option {
+	return func(t *Generic) {
+		t.schedule = s
+	}
+}
+
+// Clip limits gradients by value and by global L2 norm before every weights update, see clip.New.
+func Clip(value, norm float64) Option {
+	return func(t *Generic) {
+		t.clipValue = value
+		t.clipNorm = norm
+	}
+}
@@ -0,0 +1,63 @@
+package parallel
+
+import (
+	"github.com/drdreyworld/nnet"
+	"github.com/drdreyworld/nnet/data"
+)
+
+// replica is a worker copy of the net made of the net layers clones.
+type replica struct {
+	layers []nnet.Layer
+}
+
+func newReplica(net Net) (*replica, error) {
+	layers := make([]nnet.Layer, net.GetLayersCount())
+	for i := range layers {
+		layers[i] = net.GetLayer(i)
+	}
+
+	clones, err := nnet.CloneLayers(layers)
+	if err != nil {
+		return nil, err
+	}
+
+	return &replica{layers: clones}, nil
+}
+
+func (r *replica) Activate(inputs *data.Data) *data.Data {
+	for i := 0; i < len(r.layers); i++ {
+		inputs = r.layers[i].Activate(inputs)
+	}
+	return inputs
+}
+
+func (r *replica) Backprop(deltas *data.Data) *data.Data {
+	for i := len(r.layers) - 1; i >= 0; i-- {
+		deltas = r.layers[i].Backprop(deltas)
+	}
+	return deltas
+}
+
+func (r *replica) GetLayersCount() int {
+	return len(r.layers)
+}
+
+func (r *replica) GetLayer(index int) nnet.Layer {
+	return r.layers[index]
+}
+
+func (r *replica) Train() {
+	for _, layer := range r.layers {
+		if l, ok := layer.(nnet.LayerWithMode); ok {
+			l.Train()
+		}
+	}
+}
+
+func (r *replica) Eval() {
+	for _, layer := range r.layers {
+		if l, ok := layer.(nnet.LayerWithMode); ok {
+			l.Eval()
+		}
+	}
+}
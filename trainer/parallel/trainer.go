@@ -0,0 +1,211 @@
+package parallel
+
+import (
+	"sync"
+
+	"github.com/drdreyworld/nnet"
+	"github.com/drdreyworld/nnet/data"
+	"github.com/drdreyworld/nnet/optimizer"
+	"github.com/drdreyworld/nnet/trainer"
+)
+
+type Net interface {
+	Activate(inputs *data.Data) (output *data.Data)
+	Backprop(deltas *data.Data) (gradient *data.Data)
+	GetLayersCount() int
+	GetLayer(index int) nnet.Layer
+}
+
+// Loss is shared between workers, so GetDeltas must be safe for concurrent use.
+type Loss interface {
+	GetDeltas(target, output *data.Data) (res *data.Data)
+}
+
+// NetWithMode is implemented by nets with layers behaving differently in training and inference.
+type NetWithMode interface {
+	Train()
+	Eval()
+}
+
+// New creates data-parallel trainer. The net is the first worker, other workers
+// are replicas of the net sharing its weights, so net layers must be initialized
+// and implement nnet.LayerWithClone. Options are the options of trainer.New.
+// Statistics of the batch, like the running ones of batchnorm, are not reduced between workers,
+// layers of the net update them by the part of the batch of the first worker.
+func New(net Net, loss Loss, optimizer optimizer.Optimizer, workers int, options ...trainer.Option) (*parallel, error) {
+	if workers < 1 {
+		workers = 1
+	}
+
+	t := &parallel{
+		loss:     loss,
+		updater:  trainer.New(net, nil, optimizer, options...),
+		replicas: []Net{net},
+		counts:   make([]int, workers),
+		outputs:  make([]*data.Data, workers),
+	}
+
+	for i := 1; i < workers; i++ {
+		r, err := newReplica(net)
+		if err != nil {
+			return nil, err
+		}
+		t.replicas = append(t.replicas, r)
+	}
+
+	return t, nil
+}
+
+type parallel struct {
+	loss Loss
+
+	// updater applies the optimizer to the reduced gradients of the net
+	updater *trainer.Generic
+
+	replicas []Net
+	counts   []int
+	outputs  []*data.Data
+
+	output *data.Data
+
+	// empty is set by Activate with empty batch, so the next update is skipped
+	empty bool
+}
+
+// Activate splits the [w, h, d, n] batch between workers, runs forward and backward
+// passes concurrently and reduces workers gradients into the gradients of the net.
+// Empty batch has no output, Activate returns nil and the next UpdateWeights does nothing.
+func (t *parallel) Activate(inputs, target *data.Data) *data.Data {
+	n := inputs.GetBatchSize()
+	workers := len(t.replicas)
+
+	t.empty = n < 1
+	if t.empty {
+		t.output = nil
+		return nil
+	}
+
+	wg := sync.WaitGroup{}
+	from := 0
+
+	for k, r := range t.replicas {
+		t.counts[k] = n / workers
+		if k < n%workers {
+			t.counts[k]++
+		}
+
+		if t.counts[k] == 0 {
+			continue
+		}
+
+		wg.Add(1)
+		go func(k int, r Net, inputs, target *data.Data) {
+			defer wg.Done()
+
+			t.outputs[k] = r.Activate(inputs).Copy()
+			r.Backprop(t.loss.GetDeltas(target, t.outputs[k]))
+		}(k, r, subBatch(inputs, n, from, t.counts[k]), subBatch(target, n, from, t.counts[k]))
+
+		from += t.counts[k]
+	}
+
+	wg.Wait()
+
+	t.reduceGradients(n)
+	t.updater.Accumulate()
+	t.output = t.joinOutputs(n)
+
+	return t.output
+}
+
+func (t *parallel) UpdateWeights() {
+	if t.empty {
+		return
+	}
+	t.updater.UpdateWeights()
+}
+
+// Observe passes metric to the schedule of the trainer, see trainer.Generic.Observe.
+func (t *parallel) Observe(metric float64) {
+	t.updater.Observe(metric)
+}
+
+// GetGradientNorm returns global L2 norm of the reduced gradients of the last update before clipping,
+// it is computed only with trainer.Clip option.
+func (t *parallel) GetGradientNorm() float64 {
+	return t.updater.GetGradientNorm()
+}
+
+// Train switches the net and its replicas to training mode. Modes of replicas do not follow
+// the net, so modes are switched by the trainer, fit.Fit does it.
+func (t *parallel) Train() {
+	for _, r := range t.replicas {
+		if m, ok := r.(NetWithMode); ok {
+			m.Train()
+		}
+	}
+}
+
+// Eval switches the net and its replicas to inference mode.
+func (t *parallel) Eval() {
+	for _, r := range t.replicas {
+		if m, ok := r.(NetWithMode); ok {
+			m.Eval()
+		}
+	}
+}
+
+// reduceGradients makes the net gradients a mean over the whole batch,
+// workers gradients are already averaged over their parts of the batch by loss.
+func (t *parallel) reduceGradients(n int) {
+	params := trainer.GetParams(t.replicas[0])
+
+	for _, p := range params {
+		rate := float64(t.counts[0]) / float64(n)
+		for j := 0; j < len(p.Gradient.Data); j++ {
+			p.Gradient.Data[j] *= rate
+		}
+	}
+
+	for k := 1; k < len(t.replicas); k++ {
+		if t.counts[k] == 0 {
+			continue
+		}
+
+		rate := float64(t.counts[k]) / float64(n)
+		for i, p := range trainer.GetParams(t.replicas[k]) {
+			g := params[i].Gradient
+			for j := 0; j < len(g.Data); j++ {
+				g.Data[j] += rate * p.Gradient.Data[j]
+			}
+		}
+	}
+}
+
+func (t *parallel) joinOutputs(n int) *data.Data {
+	var w, h, d int
+	t.outputs[0].Dimensions(&w, &h, &d)
+
+	res := &data.Data{}
+	res.InitBatch(w, h, d, n)
+
+	offset := 0
+	for k := range t.replicas {
+		if t.counts[k] > 0 {
+			offset += copy(res.Data[offset:], t.outputs[k].Data)
+		}
+	}
+	return res
+}
+
+// subBatch returns count samples of the batch starting from the sample, result is linked to the batch.
+func subBatch(batch *data.Data, n, from, count int) *data.Data {
+	var w, h, d int
+	batch.Dimensions(&w, &h, &d)
+
+	volume := len(batch.Data) / n
+	return &data.Data{
+		Dims: []int{w, h, d, count},
+		Data: batch.Data[from*volume : (from+count)*volume],
+	}
+}
@@ -0,0 +1,177 @@
+package parallel
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/drdreyworld/nnet"
+	"github.com/drdreyworld/nnet/activation/sigmoid"
+	"github.com/drdreyworld/nnet/data"
+	"github.com/drdreyworld/nnet/fit"
+	"github.com/drdreyworld/nnet/layer/activation"
+	"github.com/drdreyworld/nnet/layer/batchnorm"
+	"github.com/drdreyworld/nnet/layer/conv"
+	"github.com/drdreyworld/nnet/layer/dropout"
+	"github.com/drdreyworld/nnet/layer/fc"
+	"github.com/drdreyworld/nnet/loss/regression"
+	basic_ffn "github.com/drdreyworld/nnet/net/basic-ffn"
+	"github.com/drdreyworld/nnet/optimizer/sgd"
+	"github.com/drdreyworld/nnet/schedule"
+	"github.com/drdreyworld/nnet/trainer"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func newNets(t *testing.T) (a, b trainer.Net) {
+	net := basic_ffn.New(3, 3, 1, basic_ffn.Layers{
+		conv.New(conv.FilterSize(2), conv.FiltersCount(2)),
+		activation.New(sigmoid.New()),
+		fc.New(fc.OutputSizes(2, 1, 1)),
+	})
+	assert.NoError(t, net.Init())
+
+	buf := &bytes.Buffer{}
+	assert.NoError(t, net.Save(buf))
+
+	clone := basic_ffn.New(0, 0, 0, nil)
+	assert.NoError(t, clone.Load(buf))
+
+	return net, clone
+}
+
+func TestParallel_MatchesSingleWorker(t *testing.T) {
+	testCases := map[string][]trainer.Option{
+		"Default":   {},
+		"BatchSize": {trainer.BatchSize(2)},
+		"Schedule":  {trainer.Schedule(schedule.StepDecay(0.1, 0.5, 1))},
+		"Clip":      {trainer.Clip(0.01, 0.05)},
+	}
+
+	for name, options := range testCases {
+		for _, workers := range []int{1, 2, 3, 8} {
+			single, net := newNets(t)
+
+			singleTrainer := trainer.New(single, regression.New(), sgd.New(0.1, 0.9, 0), options...)
+
+			parallelTrainer, err := New(net, regression.New(), sgd.New(0.1, 0.9, 0), workers, options...)
+			assert.NoError(t, err)
+
+			for step := 0; step < 4; step++ {
+				inputs := &data.Data{}
+				inputs.InitHiperCubeRandom(3, 3, 1, 5, -1, 1)
+
+				target := &data.Data{}
+				target.InitHiperCubeRandom(2, 1, 1, 5, 0, 1)
+
+				expected := singleTrainer.Activate(inputs, target)
+				assert.InDeltaSlice(t, expected.Data, parallelTrainer.Activate(inputs, target).Data, 1e-12)
+
+				singleTrainer.UpdateWeights()
+				parallelTrainer.UpdateWeights()
+
+				assert.InDelta(t, singleTrainer.GetGradientNorm(), parallelTrainer.GetGradientNorm(), 1e-12)
+			}
+
+			singleParams := trainer.GetParams(single)
+			for i, p := range trainer.GetParams(net) {
+				assert.InDeltaSlice(t, singleParams[i].Value.Data, p.Value.Data, 1e-12, "%s, workers: %d, param: %d", name, workers, i)
+			}
+		}
+	}
+}
+
+func TestParallel_EmptyBatch(t *testing.T) {
+	net, _ := newNets(t)
+
+	parallelTrainer, err := New(net, regression.New(), sgd.New(0.1, 0.9, 0), 3)
+	assert.NoError(t, err)
+
+	inputs := &data.Data{}
+	inputs.InitBatch(3, 3, 1, 0)
+
+	target := &data.Data{}
+	target.InitBatch(2, 1, 1, 0)
+
+	expected := []*data.Data{}
+	for _, p := range trainer.GetParams(net) {
+		expected = append(expected, p.Value.Copy())
+	}
+
+	assert.Nil(t, parallelTrainer.Activate(inputs, target))
+	parallelTrainer.UpdateWeights()
+
+	for i, p := range trainer.GetParams(net) {
+		assert.Equal(t, expected[i], p.Value, "param: %d", i)
+	}
+}
+
+func TestParallel_Modes(t *testing.T) {
+	net := basic_ffn.New(4, 4, 1, basic_ffn.Layers{dropout.New(dropout.Rate(0.5))})
+	assert.NoError(t, net.Init())
+
+	p, err := New(net, regression.New(), sgd.New(0.1, 0, 0), 2)
+	assert.NoError(t, err)
+
+	inputs := &data.Data{}
+	inputs.InitHiperCube(4, 4, 1, 4)
+	inputs.Fill(1)
+
+	p.Eval()
+	assert.Equal(t, inputs.Data, p.Activate(inputs, inputs).Data, "replicas pass inputs as is")
+
+	p.Train()
+	output := p.Activate(inputs, inputs)
+	for k := 0; k < 2; k++ {
+		assert.Contains(t, output.Data[k*32:(k+1)*32], 0.0, "worker %d drops inputs", k)
+	}
+}
+
+// TestParallel_BatchNorm runs under go test -race, replicas must not read running statistics
+// updated by the net while training.
+func TestParallel_BatchNorm(t *testing.T) {
+	net := basic_ffn.New(3, 3, 1, basic_ffn.Layers{
+		fc.New(fc.OutputSizes(4, 1, 1)),
+		batchnorm.New(),
+		fc.New(fc.OutputSizes(2, 1, 1)),
+	})
+	assert.NoError(t, net.Init())
+
+	p, err := New(net, regression.New(), sgd.New(0.1, 0, 0), 3)
+	assert.NoError(t, err)
+
+	dataset := fit.Dataset{}
+	for i := 0; i < 12; i++ {
+		inputs := &data.Data{}
+		inputs.InitCubeRandom(3, 3, 1, -1, 1)
+		dataset.Inputs = append(dataset.Inputs, inputs)
+
+		target := &data.Data{}
+		target.InitCubeRandom(2, 1, 1, 0, 1)
+		dataset.Targets = append(dataset.Targets, target)
+	}
+
+	stats, err := fit.Fit(p, net, regression.New(), dataset, fit.Epochs(2), fit.BatchSize(6), fit.ValidationSplit(0.5))
+	assert.NoError(t, err)
+	assert.Len(t, stats, 2)
+
+	statistics := net.GetLayer(1).(fit.LayerWithStatistics).GetStatistics()
+	assert.NotEqual(t, []float64{0, 0, 0, 0}, statistics[0].Data, "net updates running mean")
+}
+
+type layerStub struct{}
+
+func (l *layerStub) InitDataSizes(w, h, d int) (int, int, int) { return w, h, d }
+func (l *layerStub) Activate(inputs *data.Data) *data.Data     { return inputs }
+func (l *layerStub) Backprop(deltas *data.Data) *data.Data     { return deltas }
+
+func TestNew_NotCloneableLayer(t *testing.T) {
+	net := basic_ffn.New(1, 1, 1, basic_ffn.Layers{&layerStub{}})
+
+	p, err := New(net, regression.New(), sgd.New(0.1, 0, 0), 2)
+	assert.Nil(t, p)
+	assert.Equal(t, nnet.ErrorLayerNotCloneable, errors.Cause(err))
+
+	p, err = New(net, regression.New(), sgd.New(0.1, 0, 0), 1)
+	assert.NotNil(t, p)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,176 @@
+//go:generate mockgen -package=mocks -source=$GOFILE -destination=mocks/$GOFILE
+package trainer
+
+import (
+	"github.com/drdreyworld/nnet"
+	"github.com/drdreyworld/nnet/data"
+	"github.com/drdreyworld/nnet/optimizer"
+	"github.com/drdreyworld/nnet/optimizer/clip"
+	"github.com/drdreyworld/nnet/schedule"
+)
+
+type Net interface {
+	Activate(inputs *data.Data) (output *data.Data)
+	Backprop(deltas *data.Data) (gradient *data.Data)
+	GetLayersCount() int
+	GetLayer(index int) nnet.Layer
+}
+
+type Loss interface {
+	GetDeltas(target, output *data.Data) (res *data.Data)
+}
+
+type TrainableLayer interface {
+	nnet.Layer
+	GetWeightsWithGradient() (w, g *data.Data)
+	GetBiasesWithGradient() (w, g *data.Data)
+}
+
+type Trainer interface {
+	Activate(inputs, target *data.Data) (output *data.Data)
+	UpdateWeights()
+}
+
+func New(net Net, loss Loss, optimizer optimizer.Optimizer, options ...Option) *Generic {
+	t := &Generic{
+		net:       net,
+		loss:      loss,
+		optimizer: optimizer,
+		batchSize: 1,
+	}
+
+	for _, opt := range options {
+		opt(t)
+	}
+
+	t.wrapOptimizer()
+
+	return t
+}
+
+// Generic trains any net with any loss and optimizer, trainers of the other packages are built on it.
+type Generic struct {
+	net       Net
+	loss      Loss
+	optimizer optimizer.Optimizer
+
+	batchSize  int
+	batchIndex int
+
+	schedule  schedule.Schedule
+	clipValue float64
+	clipNorm  float64
+	clip      interface{ GetNorm() float64 }
+
+	output *data.Data
+	deltas *data.Data
+	sums   []optimizer.Param
+}
+
+// wrapOptimizer applies schedule and then clipping to the optimizer, so options order does not matter.
+func (t *Generic) wrapOptimizer() {
+	if o, ok := t.optimizer.(optimizer.OptimizerWithLearningRate); ok && t.schedule != nil {
+		t.optimizer = schedule.NewOptimizer(o, t.schedule)
+	}
+
+	if t.clipValue > 0 || t.clipNorm > 0 {
+		c := clip.New(t.optimizer, t.clipValue, t.clipNorm)
+		t.optimizer, t.clip = c, c
+	}
+}
+
+// GetParams returns weights and biases of every trainable layer of the net with their gradients.
+func GetParams(net Net) []optimizer.Param {
+	params := []optimizer.Param{}
+	for i := 0; i < net.GetLayersCount(); i++ {
+		if layer, ok := net.GetLayer(i).(TrainableLayer); ok {
+			w, g := layer.GetWeightsWithGradient()
+			params = append(params, optimizer.Param{Value: w, Gradient: g})
+
+			w, g = layer.GetBiasesWithGradient()
+			params = append(params, optimizer.Param{Value: w, Gradient: g})
+		}
+	}
+	return params
+}
+
+func (t *Generic) Activate(inputs, target *data.Data) *data.Data {
+	t.output = t.net.Activate(inputs).Copy()
+	t.deltas = t.loss.GetDeltas(target, t.output)
+
+	t.net.Backprop(t.deltas)
+	t.Accumulate()
+
+	return t.output
+}
+
+// Accumulate adds the net gradients to the batch with BatchSize option. Activate calls it after backprop,
+// trainers computing the net gradients by themselves, like the parallel one, call it instead.
+func (t *Generic) Accumulate() {
+	if t.batchSize > 1 {
+		t.accumulate()
+	}
+}
+
+func (t *Generic) accumulate() {
+	params := GetParams(t.net)
+
+	if len(t.sums) == 0 {
+		t.sums = make([]optimizer.Param, len(params))
+		for k, p := range params {
+			t.sums[k] = optimizer.Param{Value: p.Value, Gradient: p.Gradient.CopyZero()}
+		}
+	}
+
+	for k, p := range params {
+		for j := 0; j < len(p.Gradient.Data); j++ {
+			t.sums[k].Gradient.Data[j] += p.Gradient.Data[j]
+		}
+	}
+
+	t.batchIndex++
+}
+
+// UpdateWeights applies the optimizer step. With batch size greater than one
+// the step is applied only after batch size activations with their mean gradients.
+func (t *Generic) UpdateWeights() {
+	if t.batchSize < 2 {
+		t.optimizer.Step(GetParams(t.net))
+		return
+	}
+
+	if t.batchIndex < t.batchSize {
+		return
+	}
+	t.batchIndex = 0
+
+	batchRate := 1 / float64(t.batchSize)
+	for _, p := range t.sums {
+		for j := 0; j < len(p.Gradient.Data); j++ {
+			p.Gradient.Data[j] *= batchRate
+		}
+	}
+
+	t.optimizer.Step(t.sums)
+
+	for _, p := range t.sums {
+		p.Gradient.Reset()
+	}
+}
+
+// Observe passes metric, usually validation loss of the epoch, to the schedule implementing
+// schedule.Observer, fit.Fit calls it after every epoch.
+func (t *Generic) Observe(metric float64) {
+	if o, ok := t.schedule.(schedule.Observer); ok {
+		o.Observe(metric)
+	}
+}
+
+// GetGradientNorm returns global L2 norm of the gradients of the last update before clipping,
+// it is computed only with Clip option.
+func (t *Generic) GetGradientNorm() float64 {
+	if t.clip == nil {
+		return 0
+	}
+	return t.clip.GetNorm()
+}
@@ -0,0 +1,200 @@
+package trainer
+
+import (
+	"github.com/drdreyworld/nnet/data"
+	"github.com/drdreyworld/nnet/layer/softmax"
+	"github.com/drdreyworld/nnet/optimizer"
+	"github.com/drdreyworld/nnet/optimizer/clip"
+	"github.com/drdreyworld/nnet/optimizer/sgd"
+	"github.com/drdreyworld/nnet/schedule"
+	"github.com/drdreyworld/nnet/trainer/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+type optimizerStub struct {
+	steps [][]optimizer.Param
+}
+
+func (o *optimizerStub) Step(params []optimizer.Param) {
+	copied := make([]optimizer.Param, len(params))
+	for i, p := range params {
+		copied[i] = optimizer.Param{Value: p.Value, Gradient: p.Gradient.Copy()}
+	}
+	o.steps = append(o.steps, copied)
+}
+
+func TestTrainer_Activate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	inputs := data.NewVector(1, 0)
+	target := data.NewVector(1)
+	netOutput := data.NewVector(0.3)
+	netDeltas := data.NewVector(0.7)
+
+	loss := mocks.NewMockLoss(ctrl)
+	loss.EXPECT().GetDeltas(target, netOutput).Return(netDeltas)
+
+	net := mocks.NewMockNet(ctrl)
+	net.EXPECT().Activate(inputs).Return(netOutput)
+	net.EXPECT().Backprop(netDeltas)
+
+	trainer := New(net, loss, &optimizerStub{})
+
+	assert.EqualValues(t, netOutput, trainer.Activate(inputs, target))
+}
+
+func TestTrainer_UpdateWeights(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	net := mocks.NewMockNet(ctrl)
+	net.EXPECT().GetLayersCount().Return(2).AnyTimes()
+
+	layer := mocks.NewMockTrainableLayer(ctrl)
+	net.EXPECT().GetLayer(0).Return(layer)
+	net.EXPECT().GetLayer(1).Return(softmax.New())
+
+	layerWeights := data.NewVector(0.11, 0.22, 0.33)
+	layerWeightsGradients := data.NewVector(0.1, 0.2, 0.3)
+
+	layerBiases := data.NewVector(0.1, 0.2, 0.3)
+	layerBiasesGradients := data.NewVector(0.3, 0.4, 0.5)
+
+	layer.EXPECT().GetWeightsWithGradient().Return(layerWeights, layerWeightsGradients)
+	layer.EXPECT().GetBiasesWithGradient().Return(layerBiases, layerBiasesGradients)
+
+	o := &optimizerStub{}
+	New(net, nil, o).UpdateWeights()
+
+	assert.Equal(t, [][]optimizer.Param{{
+		{Value: layerWeights, Gradient: layerWeightsGradients},
+		{Value: layerBiases, Gradient: layerBiasesGradients},
+	}}, o.steps)
+}
+
+func TestTrainer_UpdateWeightsWithBatchSize(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	inputs := data.NewVector(1, 0)
+	target := data.NewVector(1)
+	netOutput := data.NewVector(0.3)
+	netDeltas := data.NewVector(0.7)
+
+	loss := mocks.NewMockLoss(ctrl)
+	loss.EXPECT().GetDeltas(target, netOutput).Return(netDeltas).AnyTimes()
+
+	net := mocks.NewMockNet(ctrl)
+	net.EXPECT().Activate(inputs).Return(netOutput).AnyTimes()
+	net.EXPECT().Backprop(netDeltas).AnyTimes()
+	net.EXPECT().GetLayersCount().Return(1).AnyTimes()
+
+	layer := mocks.NewMockTrainableLayer(ctrl)
+	net.EXPECT().GetLayer(0).Return(layer).AnyTimes()
+
+	layerWeights := data.NewVector(0.11, 0.22)
+	layerWeightsGradients := data.NewVector(0.1, 0.2)
+
+	layerBiases := data.NewVector(0.1)
+	layerBiasesGradients := data.NewVector(0.3)
+
+	layer.EXPECT().GetWeightsWithGradient().Return(layerWeights, layerWeightsGradients).AnyTimes()
+	layer.EXPECT().GetBiasesWithGradient().Return(layerBiases, layerBiasesGradients).AnyTimes()
+
+	o := &optimizerStub{}
+	trainer := New(net, loss, o, BatchSize(2))
+
+	trainer.Activate(inputs, target)
+	trainer.UpdateWeights()
+	assert.Empty(t, o.steps, "step before batch is complete")
+
+	layerWeightsGradients.Data = []float64{0.3, 0.4}
+	layerBiasesGradients.Data = []float64{0.5}
+
+	trainer.Activate(inputs, target)
+	trainer.UpdateWeights()
+
+	assert.Equal(t, [][]optimizer.Param{{
+		{Value: layerWeights, Gradient: data.NewVector(0.2, 0.30000000000000004)},
+		{Value: layerBiases, Gradient: data.NewVector(0.4)},
+	}}, o.steps)
+}
+
+func TestTrainer_Schedule(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	net := mocks.NewMockNet(ctrl)
+	net.EXPECT().GetLayersCount().Return(0).AnyTimes()
+
+	o := sgd.New(1, 0, 0)
+	trainer := New(net, nil, o, Schedule(schedule.StepDecay(0.4, 0.5, 2)))
+
+	rates := []float64{}
+	for i := 0; i < 4; i++ {
+		trainer.UpdateWeights()
+		rates = append(rates, o.GetLearningRate())
+	}
+
+	assert.Equal(t, []float64{0.4, 0.4, 0.2, 0.2}, rates)
+}
+
+func TestTrainer_ScheduleClippedOptimizer(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	net := mocks.NewMockNet(ctrl)
+	net.EXPECT().GetLayersCount().Return(0).AnyTimes()
+
+	o := sgd.New(1, 0, 0)
+	trainer := New(net, nil, clip.New(o, 0, 1), Schedule(schedule.Constant(0.3)))
+	trainer.UpdateWeights()
+
+	assert.Equal(t, 0.3, o.GetLearningRate())
+}
+
+func TestTrainer_Observe(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	net := mocks.NewMockNet(ctrl)
+	net.EXPECT().GetLayersCount().Return(0).AnyTimes()
+
+	o := sgd.New(1, 0, 0)
+	trainer := New(net, nil, o, Schedule(schedule.NewReduceOnPlateau(0.4, 0.5, 0, 0, 0)))
+
+	trainer.Observe(1)
+	trainer.UpdateWeights()
+	assert.Equal(t, 0.4, o.GetLearningRate())
+
+	trainer.Observe(1)
+	trainer.UpdateWeights()
+	assert.Equal(t, 0.2, o.GetLearningRate())
+}
+
+func TestTrainer_Clip(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	net := mocks.NewMockNet(ctrl)
+	net.EXPECT().GetLayersCount().Return(1).AnyTimes()
+
+	layer := mocks.NewMockTrainableLayer(ctrl)
+	net.EXPECT().GetLayer(0).Return(layer)
+
+	weights := data.NewVector(1, 1)
+	biases := data.NewVector(1)
+
+	layer.EXPECT().GetWeightsWithGradient().Return(weights, data.NewVector(3, 0))
+	layer.EXPECT().GetBiasesWithGradient().Return(biases, data.NewVector(4))
+
+	trainer := New(net, nil, sgd.New(1, 0, 0), Clip(0, 1), Schedule(schedule.Constant(0.5)))
+	trainer.UpdateWeights()
+
+	assert.InDeltaSlice(t, []float64{0.7, 1}, weights.Data, 1e-12)
+	assert.InDeltaSlice(t, []float64{0.6}, biases.Data, 1e-12)
+	assert.Equal(t, 5.0, trainer.GetGradientNorm())
+}
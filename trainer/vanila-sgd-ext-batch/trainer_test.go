@@ -0,0 +1,80 @@
+package vanila_sgd_ext_batch
+
+import (
+	"github.com/drdreyworld/nnet/data"
+	"github.com/drdreyworld/nnet/trainer/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestTrainer_Activate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	inputs := data.NewVector(1, 0)
+	target := data.NewVector(1)
+	netOutput := data.NewVector(0.3)
+	netDeltas := data.NewVector(0.7)
+
+	loss := mocks.NewMockLoss(ctrl)
+	loss.EXPECT().GetDeltas(target, netOutput).Return(netDeltas)
+
+	net := mocks.NewMockNet(ctrl)
+	net.EXPECT().Activate(inputs).Return(netOutput)
+	net.EXPECT().Backprop(netDeltas)
+	net.EXPECT().GetLayersCount().Return(0).AnyTimes()
+
+	trainer := New(net, loss, 2, 0.12, 0.07, 0.01)
+
+	assert.EqualValues(t, netOutput, trainer.Activate(inputs, target))
+	assert.Equal(t, 0.0, trainer.GetGradientNorm())
+}
+
+func TestTrainer_UpdateWeights(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	inputs := data.NewVector(1, 0, 1)
+	target := data.NewVector(1, 0, 0)
+
+	netOutput := data.NewVector(0.3, 0, 0)
+	netDeltas := data.NewVector(0.7, 0, 0)
+
+	loss := mocks.NewMockLoss(ctrl)
+	loss.EXPECT().GetDeltas(target, netOutput).Return(netDeltas).Times(2)
+
+	net := mocks.NewMockNet(ctrl)
+	net.EXPECT().Activate(inputs).Return(netOutput).Times(2)
+	net.EXPECT().Backprop(netDeltas).Times(2)
+	net.EXPECT().GetLayersCount().Return(1).AnyTimes()
+
+	layer := mocks.NewMockTrainableLayer(ctrl)
+	net.EXPECT().GetLayer(0).Return(layer).AnyTimes()
+
+	layerWeights := data.NewVector(0.1, 0.2, 0.3)
+	layerWeightsGradients := data.NewVector(0.1, 0.2, 0.3)
+
+	layerBiases := data.NewVector(0.5, 0.5, 0.7)
+	layerBiasesGradients := data.NewVector(0.3, 0.4, 0.5)
+
+	layer.EXPECT().GetWeightsWithGradient().Return(layerWeights, layerWeightsGradients).AnyTimes()
+	layer.EXPECT().GetBiasesWithGradient().Return(layerBiases, layerBiasesGradients).AnyTimes()
+
+	trainer := New(net, loss, 2, 0.12, 0.07, 0.01)
+
+	trainer.Activate(inputs, target)
+	trainer.UpdateWeights()
+
+	assert.EqualValues(t, data.NewVector(0.1, 0.2, 0.3), layerWeights, "weights are updated after the batch")
+
+	trainer.Activate(inputs, target)
+	trainer.UpdateWeights()
+
+	// mean gradient multiplied by learning rate, weight decay is ignored
+	assert.InDeltaSlice(t, []float64{0.088, 0.176, 0.264}, layerWeights.Data, 1e-12)
+	assert.InDeltaSlice(t, []float64{0.464, 0.452, 0.64}, layerBiases.Data, 1e-12)
+
+	assert.EqualValues(t, data.NewVector(0.1, 0.2, 0.3), layerWeightsGradients)
+	assert.EqualValues(t, data.NewVector(0.3, 0.4, 0.5), layerBiasesGradients)
+}
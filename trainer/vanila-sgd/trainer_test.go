@@ -2,7 +2,7 @@ package vanila_sgd
 
 import (
 	"github.com/drdreyworld/nnet/data"
-	"github.com/drdreyworld/nnet/trainer/vanila-sgd/mocks"
+	"github.com/drdreyworld/nnet/trainer/mocks"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 	"testing"